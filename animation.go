@@ -0,0 +1,636 @@
+package genppt
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AnimationOptions 对象动画选项
+type AnimationOptions struct {
+	Effect      AnimationEffect     // 动画效果
+	Exit        bool                // 退出动画，默认为进入动画（脉冲为强调效果，忽略此项）
+	Trigger     AnimationTrigger    // 开始方式，默认单击时
+	Direction   TransitionDirection // 飞入、擦除的方向（从哪一侧进入），默认从下方
+	Delay       float64             // 延迟（秒）
+	Duration    float64             // 持续时间（秒），0为默认0.5秒
+	ByParagraph bool                // 按段落逐条播放（仅用于文本框、占位符和带文本的形状），没有文字的段落不单独播放
+}
+
+// animationStep 时间轴中的一个动画
+type animationStep struct {
+	spid      int
+	anim      *AnimationOptions
+	paragraph int // 按段落播放时的段落序号，-1表示整个对象
+}
+
+// animationBuild 动画对象的构建信息（p:bldLst）
+type animationBuild struct {
+	spid        int
+	graphic     bool // 表格、图表等图形框
+	byParagraph bool
+}
+
+// objectAnimation 返回对象的动画设置
+func objectAnimation(obj slideObject) *AnimationOptions {
+	switch o := obj.(type) {
+	case *textObject:
+		return o.options.Animation
+	case *shapeObject:
+		return o.options.Animation
+	case *tableObject:
+		return o.options.Animation
+	case *imageObject:
+		return o.options.Animation
+	case *chartObject:
+		return o.options.Animation
+	case *videoObject:
+		return o.options.Animation
+	case *audioObject:
+		return o.options.Animation
+	case *placeholderObject:
+		return o.animation
+	}
+	return nil
+}
+
+// animatedParagraphs 返回对象中可以逐条播放的段落序号，跳过没有文字的段落，不支持时返回 nil
+func animatedParagraphs(obj slideObject) []int {
+	var indexes []int
+	switch o := obj.(type) {
+	case *textObject:
+		for i, para := range o.textParagraphs() {
+			for _, run := range para.Runs {
+				if strings.TrimSpace(run.Text) != "" {
+					indexes = append(indexes, i)
+					break
+				}
+			}
+		}
+	case *shapeObject:
+		if strings.TrimSpace(o.text) != "" {
+			indexes = append(indexes, 0)
+		}
+	case *placeholderObject:
+		for i, line := range strings.Split(strings.ReplaceAll(o.text, "\r\n", "\n"), "\n") {
+			if strings.TrimSpace(line) != "" {
+				indexes = append(indexes, i)
+			}
+		}
+	}
+	return indexes
+}
+
+// animationSteps 将对象动画展开为时间轴步骤，按段落播放时每段一个步骤
+func animationSteps(obj slideObject, spid int) ([]animationStep, *animationBuild) {
+	anim := objectAnimation(obj)
+	if anim == nil {
+		return nil, nil
+	}
+
+	var build *animationBuild
+	switch obj.(type) {
+	case *textObject, *shapeObject, *placeholderObject:
+		build = &animationBuild{spid: spid}
+	case *tableObject, *chartObject:
+		build = &animationBuild{spid: spid, graphic: true}
+	}
+
+	paragraphs := animatedParagraphs(obj)
+	if !anim.ByParagraph || len(paragraphs) == 0 {
+		return []animationStep{{spid: spid, anim: anim, paragraph: -1}}, build
+	}
+	build.byParagraph = true
+	steps := make([]animationStep, 0, len(paragraphs))
+	for _, i := range paragraphs {
+		steps = append(steps, animationStep{spid: spid, anim: anim, paragraph: i})
+	}
+	return steps, build
+}
+
+// durationMS 返回动画持续时间（毫秒）
+func (a *AnimationOptions) durationMS() int {
+	if a.Effect == AnimationAppear {
+		return 1
+	}
+	return max(1, int(defaultIfZero(a.Duration, 0.5)*1000))
+}
+
+// trigger 返回动画的开始方式
+func (a *AnimationOptions) trigger() AnimationTrigger {
+	if a.Trigger == "" {
+		return TriggerOnClick
+	}
+	return a.Trigger
+}
+
+// presetClass 返回动画的预设类别
+func (a *AnimationOptions) presetClass() string {
+	switch {
+	case a.Effect == AnimationPulse:
+		return "emph"
+	case a.Exit:
+		return "exit"
+	}
+	return "entr"
+}
+
+// presetID 返回 PowerPoint 动画预设编号
+func (a *AnimationOptions) presetID() int {
+	switch a.Effect {
+	case AnimationFade:
+		return 10
+	case AnimationFlyIn:
+		return 2
+	case AnimationZoom:
+		return 53
+	case AnimationWipe:
+		return 22
+	case AnimationPulse:
+		return 26
+	}
+	return 1
+}
+
+// presetSubtype 返回方向对应的预设子类型（上1、右2、下4、左8）
+func (a *AnimationOptions) presetSubtype() int {
+	switch a.Effect {
+	case AnimationFlyIn, AnimationWipe:
+		switch a.Direction {
+		case DirectionUp:
+			return 1
+		case DirectionRight:
+			return 2
+		case DirectionLeft:
+			return 8
+		}
+		return 4
+	case AnimationZoom:
+		return 16
+	}
+	return 0
+}
+
+// flyFrom 返回飞入起点（飞出终点）的 ppt_x、ppt_y 公式
+func (a *AnimationOptions) flyFrom() (string, string) {
+	switch a.Direction {
+	case DirectionUp:
+		return "#ppt_x", "0-#ppt_h/2"
+	case DirectionLeft:
+		return "0-#ppt_w/2", "#ppt_y"
+	case DirectionRight:
+		return "1+#ppt_w/2", "#ppt_y"
+	}
+	return "#ppt_x", "1+#ppt_h/2"
+}
+
+// wipeFilter 返回擦除效果的滤镜（从下方擦除即向上擦除）
+func (a *AnimationOptions) wipeFilter() string {
+	switch a.Direction {
+	case DirectionUp:
+		return "wipe(down)"
+	case DirectionLeft:
+		return "wipe(right)"
+	case DirectionRight:
+		return "wipe(left)"
+	}
+	return "wipe(up)"
+}
+
+// timelineWriter 生成时间轴节点，负责分配 p:cTn 的ID
+type timelineWriter struct {
+	sb        strings.Builder
+	tnID      int
+	appending bool // 追加到已有的动画之后，第一组不随幻灯片自动开始
+}
+
+// nextID 返回下一个时间节点ID
+func (w *timelineWriter) nextID() string {
+	id := itoa(w.tnID)
+	w.tnID++
+	return id
+}
+
+// writeAnimations 写入对象动画，单击开始新的动画组，"之后"的动画在组内顺延
+// 第一组不是单击开始时随幻灯片自动开始
+func (w *timelineWriter) writeAnimations(steps []animationStep) {
+	type subGroup struct {
+		offset int
+		steps  []animationStep
+	}
+	type clickGroup struct {
+		auto bool
+		subs []*subGroup
+	}
+
+	var groups []*clickGroup
+	var cur *clickGroup
+	groupEnd := 0
+	for _, step := range steps {
+		trigger := step.anim.trigger()
+		switch {
+		case cur == nil || trigger == TriggerOnClick:
+			cur = &clickGroup{auto: trigger != TriggerOnClick && len(groups) == 0 && !w.appending}
+			cur.subs = []*subGroup{{}}
+			groups = append(groups, cur)
+			groupEnd = 0
+		case trigger == TriggerAfterPrevious:
+			cur.subs = append(cur.subs, &subGroup{offset: groupEnd})
+		}
+		sub := cur.subs[len(cur.subs)-1]
+		sub.steps = append(sub.steps, step)
+		groupEnd = max(groupEnd, sub.offset+int(step.anim.Delay*1000)+step.anim.durationMS())
+	}
+
+	for _, group := range groups {
+		w.sb.WriteString(`<p:par>`)
+		w.sb.WriteString(`<p:cTn id="`)
+		w.sb.WriteString(w.nextID())
+		w.sb.WriteString(`" fill="hold">`)
+		w.sb.WriteString(`<p:stCondLst>`)
+		w.sb.WriteString(`<p:cond delay="indefinite"/>`)
+		if group.auto {
+			// 第一组不是单击开始时，随幻灯片自动开始
+			w.sb.WriteString(`<p:cond evt="onBegin" delay="0"><p:tn val="2"/></p:cond>`)
+		}
+		w.sb.WriteString(`</p:stCondLst>`)
+		w.sb.WriteString(`<p:childTnLst>`)
+		for _, sub := range group.subs {
+			w.sb.WriteString(`<p:par>`)
+			w.sb.WriteString(`<p:cTn id="`)
+			w.sb.WriteString(w.nextID())
+			w.sb.WriteString(`" fill="hold">`)
+			w.sb.WriteString(`<p:stCondLst><p:cond delay="`)
+			w.sb.WriteString(itoa(sub.offset))
+			w.sb.WriteString(`"/></p:stCondLst>`)
+			w.sb.WriteString(`<p:childTnLst>`)
+			for _, step := range sub.steps {
+				w.writeEffect(step)
+			}
+			w.sb.WriteString(`</p:childTnLst>`)
+			w.sb.WriteString(`</p:cTn>`)
+			w.sb.WriteString(`</p:par>`)
+		}
+		w.sb.WriteString(`</p:childTnLst>`)
+		w.sb.WriteString(`</p:cTn>`)
+		w.sb.WriteString(`</p:par>`)
+	}
+}
+
+// writeEffect 写入单个动画效果
+func (w *timelineWriter) writeEffect(step animationStep) {
+	anim := step.anim
+	nodeType := "clickEffect"
+	switch anim.trigger() {
+	case TriggerWithPrevious:
+		nodeType = "withEffect"
+	case TriggerAfterPrevious:
+		nodeType = "afterEffect"
+	}
+
+	w.sb.WriteString(`<p:par>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" presetID="`)
+	w.sb.WriteString(itoa(anim.presetID()))
+	w.sb.WriteString(`" presetClass="`)
+	w.sb.WriteString(anim.presetClass())
+	w.sb.WriteString(`" presetSubtype="`)
+	w.sb.WriteString(itoa(anim.presetSubtype()))
+	w.sb.WriteString(`" fill="hold" grpId="0" nodeType="`)
+	w.sb.WriteString(nodeType)
+	w.sb.WriteString(`">`)
+	w.sb.WriteString(`<p:stCondLst><p:cond delay="`)
+	w.sb.WriteString(itoa(int(anim.Delay * 1000)))
+	w.sb.WriteString(`"/></p:stCondLst>`)
+	w.sb.WriteString(`<p:childTnLst>`)
+
+	target := animationTarget(step.spid, step.paragraph)
+	dur := anim.durationMS()
+	switch {
+	case anim.Effect == AnimationPulse:
+		w.writeScale(target, dur)
+	case anim.Exit:
+		switch anim.Effect {
+		case AnimationFade:
+			w.writeFilter(target, "out", "fade", dur)
+		case AnimationFlyIn:
+			x, y := anim.flyFrom()
+			w.writeMotion(target, "ppt_x", "#ppt_x", x, dur)
+			w.writeMotion(target, "ppt_y", "#ppt_y", y, dur)
+		case AnimationZoom:
+			w.writeMotion(target, "ppt_w", "#ppt_w", "0", dur)
+			w.writeMotion(target, "ppt_h", "#ppt_h", "0", dur)
+			w.writeFilter(target, "out", "fade", dur)
+		case AnimationWipe:
+			w.writeFilter(target, "out", anim.wipeFilter(), dur)
+		}
+		w.writeVisibility(target, "hidden", dur-1)
+	default:
+		w.writeVisibility(target, "visible", 0)
+		switch anim.Effect {
+		case AnimationFade:
+			w.writeFilter(target, "in", "fade", dur)
+		case AnimationFlyIn:
+			x, y := anim.flyFrom()
+			w.writeMotion(target, "ppt_x", x, "#ppt_x", dur)
+			w.writeMotion(target, "ppt_y", y, "#ppt_y", dur)
+		case AnimationZoom:
+			w.writeMotion(target, "ppt_w", "0", "#ppt_w", dur)
+			w.writeMotion(target, "ppt_h", "0", "#ppt_h", dur)
+			w.writeFilter(target, "in", "fade", dur)
+		case AnimationWipe:
+			w.writeFilter(target, "in", anim.wipeFilter(), dur)
+		}
+	}
+
+	w.sb.WriteString(`</p:childTnLst>`)
+	w.sb.WriteString(`</p:cTn>`)
+	w.sb.WriteString(`</p:par>`)
+}
+
+// animationTarget 生成动画目标 p:tgtEl，paragraph 不小于0时只作用于该段落
+func animationTarget(spid, paragraph int) string {
+	var sb strings.Builder
+	sb.WriteString(`<p:tgtEl><p:spTgt spid="`)
+	sb.WriteString(itoa(spid))
+	if paragraph < 0 {
+		sb.WriteString(`"/></p:tgtEl>`)
+		return sb.String()
+	}
+	sb.WriteString(`"><p:txEl><p:pRg st="`)
+	sb.WriteString(itoa(paragraph))
+	sb.WriteString(`" end="`)
+	sb.WriteString(itoa(paragraph))
+	sb.WriteString(`"/></p:txEl></p:spTgt></p:tgtEl>`)
+	return sb.String()
+}
+
+// writeVisibility 写入显示/隐藏对象的 p:set
+func (w *timelineWriter) writeVisibility(target, visibility string, delay int) {
+	w.sb.WriteString(`<p:set>`)
+	w.sb.WriteString(`<p:cBhvr>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" dur="1" fill="hold">`)
+	w.sb.WriteString(`<p:stCondLst><p:cond delay="`)
+	w.sb.WriteString(itoa(max(0, delay)))
+	w.sb.WriteString(`"/></p:stCondLst>`)
+	w.sb.WriteString(`</p:cTn>`)
+	w.sb.WriteString(target)
+	w.sb.WriteString(`<p:attrNameLst><p:attrName>style.visibility</p:attrName></p:attrNameLst>`)
+	w.sb.WriteString(`</p:cBhvr>`)
+	w.sb.WriteString(`<p:to><p:strVal val="`)
+	w.sb.WriteString(visibility)
+	w.sb.WriteString(`"/></p:to>`)
+	w.sb.WriteString(`</p:set>`)
+}
+
+// writeFilter 写入滤镜效果 p:animEffect（淡化、擦除）
+func (w *timelineWriter) writeFilter(target, transition, filter string, dur int) {
+	w.sb.WriteString(`<p:animEffect transition="`)
+	w.sb.WriteString(transition)
+	w.sb.WriteString(`" filter="`)
+	w.sb.WriteString(filter)
+	w.sb.WriteString(`">`)
+	w.sb.WriteString(`<p:cBhvr>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" dur="`)
+	w.sb.WriteString(itoa(dur))
+	w.sb.WriteString(`"/>`)
+	w.sb.WriteString(target)
+	w.sb.WriteString(`</p:cBhvr>`)
+	w.sb.WriteString(`</p:animEffect>`)
+}
+
+// writeMotion 写入属性插值动画 p:anim（位置、大小）
+func (w *timelineWriter) writeMotion(target, attr, from, to string, dur int) {
+	w.sb.WriteString(`<p:anim calcmode="lin" valueType="num">`)
+	w.sb.WriteString(`<p:cBhvr additive="base">`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" dur="`)
+	w.sb.WriteString(itoa(dur))
+	w.sb.WriteString(`" fill="hold"/>`)
+	w.sb.WriteString(target)
+	w.sb.WriteString(`<p:attrNameLst><p:attrName>`)
+	w.sb.WriteString(attr)
+	w.sb.WriteString(`</p:attrName></p:attrNameLst>`)
+	w.sb.WriteString(`</p:cBhvr>`)
+	w.sb.WriteString(`<p:tavLst>`)
+	w.sb.WriteString(`<p:tav tm="0"><p:val><p:strVal val="`)
+	w.sb.WriteString(from)
+	w.sb.WriteString(`"/></p:val></p:tav>`)
+	w.sb.WriteString(`<p:tav tm="100000"><p:val><p:strVal val="`)
+	w.sb.WriteString(to)
+	w.sb.WriteString(`"/></p:val></p:tav>`)
+	w.sb.WriteString(`</p:tavLst>`)
+	w.sb.WriteString(`</p:anim>`)
+}
+
+// writeScale 写入脉冲效果：放大后自动还原
+func (w *timelineWriter) writeScale(target string, dur int) {
+	w.sb.WriteString(`<p:animScale>`)
+	w.sb.WriteString(`<p:cBhvr>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" dur="`)
+	w.sb.WriteString(itoa(max(1, dur/2)))
+	w.sb.WriteString(`" autoRev="1" fill="hold"/>`)
+	w.sb.WriteString(target)
+	w.sb.WriteString(`</p:cBhvr>`)
+	w.sb.WriteString(`<p:by x="105000" y="105000"/>`)
+	w.sb.WriteString(`</p:animScale>`)
+}
+
+// generateBuildList 生成构建列表 p:bldLst
+func generateBuildList(builds []*animationBuild) string {
+	if len(builds) == 0 {
+		return ""
+	}
+	return `<p:bldLst>` + generateBuildEntries(builds) + `</p:bldLst>`
+}
+
+// generateBuildEntries 生成构建列表中的各项
+func generateBuildEntries(builds []*animationBuild) string {
+	var sb strings.Builder
+	for _, build := range builds {
+		if build.graphic {
+			sb.WriteString(`<p:bldGraphic spid="`)
+			sb.WriteString(itoa(build.spid))
+			sb.WriteString(`" grpId="0"><p:bldAsOne/></p:bldGraphic>`)
+			continue
+		}
+		sb.WriteString(`<p:bldP spid="`)
+		sb.WriteString(itoa(build.spid))
+		if build.byParagraph {
+			sb.WriteString(`" grpId="0" build="p"/>`)
+		} else {
+			sb.WriteString(`" grpId="0" animBg="1"/>`)
+		}
+	}
+	return sb.String()
+}
+
+// writeMediaCall 写入随幻灯片开始播放媒体的节点
+func (w *timelineWriter) writeMediaCall(spid int) {
+	w.sb.WriteString(`<p:par>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" fill="hold">`)
+	w.sb.WriteString(`<p:stCondLst><p:cond delay="0"/></p:stCondLst>`)
+	w.sb.WriteString(`<p:childTnLst>`)
+	w.sb.WriteString(`<p:par>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" fill="hold">`)
+	w.sb.WriteString(`<p:stCondLst><p:cond delay="0"/></p:stCondLst>`)
+	w.sb.WriteString(`<p:childTnLst>`)
+	w.sb.WriteString(`<p:par>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" presetID="1" presetClass="mediacall" presetSubtype="0" fill="hold" nodeType="afterEffect">`)
+	w.sb.WriteString(`<p:stCondLst><p:cond delay="0"/></p:stCondLst>`)
+	w.sb.WriteString(`<p:childTnLst>`)
+
+	// 媒体命令
+	w.sb.WriteString(`<p:cmd type="call" cmd="playFrom(0.0)">`)
+	w.sb.WriteString(`<p:cBhvr>`)
+	w.sb.WriteString(`<p:cTn id="`)
+	w.sb.WriteString(w.nextID())
+	w.sb.WriteString(`" dur="1" fill="hold"/>`)
+	w.sb.WriteString(`<p:tgtEl><p:spTgt spid="`)
+	w.sb.WriteString(itoa(spid))
+	w.sb.WriteString(`"/></p:tgtEl>`)
+	w.sb.WriteString(`</p:cBhvr>`)
+	w.sb.WriteString(`</p:cmd>`)
+
+	w.sb.WriteString(`</p:childTnLst>`)
+	w.sb.WriteString(`</p:cTn>`)
+	w.sb.WriteString(`</p:par>`)
+	w.sb.WriteString(`</p:childTnLst>`)
+	w.sb.WriteString(`</p:cTn>`)
+	w.sb.WriteString(`</p:par>`)
+	w.sb.WriteString(`</p:childTnLst>`)
+	w.sb.WriteString(`</p:cTn>`)
+	w.sb.WriteString(`</p:par>`)
+}
+
+// generateMainSequence 生成主动画序列 p:seq，children 为其中的节点
+func generateMainSequence(id int, children string) string {
+	var sb strings.Builder
+	sb.WriteString(`<p:seq concurrent="1" nextAc="seek">`)
+	sb.WriteString(`<p:cTn id="`)
+	sb.WriteString(itoa(id))
+	sb.WriteString(`" dur="indefinite" nodeType="mainSeq">`)
+	sb.WriteString(`<p:childTnLst>`)
+	sb.WriteString(children)
+	sb.WriteString(`</p:childTnLst>`)
+	sb.WriteString(`</p:cTn>`)
+	sb.WriteString(`<p:prevCondLst>`)
+	sb.WriteString(`<p:cond evt="onPrev" delay="0"><p:tgtEl><p:sldTgt/></p:tgtEl></p:cond>`)
+	sb.WriteString(`</p:prevCondLst>`)
+	sb.WriteString(`<p:nextCondLst>`)
+	sb.WriteString(`<p:cond evt="onNext" delay="0"><p:tgtEl><p:sldTgt/></p:tgtEl></p:cond>`)
+	sb.WriteString(`</p:nextCondLst>`)
+	sb.WriteString(`</p:seq>`)
+	return sb.String()
+}
+
+// timeNodeIDRe 匹配时间节点的ID
+var timeNodeIDRe = regexp.MustCompile(`<p:cTn\b[^>]*?\bid="(\d+)"`)
+
+// mergeTiming 把新的媒体和动画合并到原有的时间轴 p:timing 中，原有动画保持不变
+// 自动播放的媒体放在主序列开头，新的动画组追加在原有动画组之后，构建列表追加到原有列表
+// 原有时间轴没有根节点时返回 false
+func mergeTiming(raw string, media []int, steps []animationStep, builds []*animationBuild) (string, bool) {
+	maxID := 0
+	for _, m := range timeNodeIDRe.FindAllStringSubmatch(raw, -1) {
+		if id, err := strconv.Atoi(m[1]); err == nil {
+			maxID = max(maxID, id)
+		}
+	}
+
+	timeline := &timelineWriter{tnID: maxID + 1, appending: true}
+	for _, id := range media {
+		timeline.writeMediaCall(id)
+	}
+	head := timeline.sb.String()
+	timeline.sb.Reset()
+	timeline.writeAnimations(steps)
+	tail := timeline.sb.String()
+
+	merged, ok := insertTimeNodes(raw, `nodeType="mainSeq"`, head, tail)
+	if !ok {
+		// 没有主序列（如只有触发器动画），在根节点中添加
+		merged, ok = insertTimeNodes(raw, `nodeType="tmRoot"`, generateMainSequence(timeline.tnID, head+tail), "")
+		if !ok {
+			return "", false
+		}
+	}
+
+	if len(builds) > 0 {
+		entries := generateBuildEntries(builds)
+		switch {
+		case strings.Contains(merged, `</p:bldLst>`):
+			merged = strings.Replace(merged, `</p:bldLst>`, entries+`</p:bldLst>`, 1)
+		case strings.Contains(merged, `<p:bldLst/>`):
+			merged = strings.Replace(merged, `<p:bldLst/>`, `<p:bldLst>`+entries+`</p:bldLst>`, 1)
+		default:
+			// 构建列表位于 p:tnLst 之后、p:extLst 之前
+			i := strings.LastIndex(merged, `<p:extLst`)
+			if i < 0 {
+				i = strings.LastIndex(merged, `</p:timing>`)
+			}
+			merged = merged[:i] + `<p:bldLst>` + entries + `</p:bldLst>` + merged[i:]
+		}
+	}
+	return merged, true
+}
+
+// insertTimeNodes 在带有 marker 属性的 p:cTn 的子节点列表开头插入 head、末尾插入 tail
+func insertTimeNodes(xmlStr, marker, head, tail string) (string, bool) {
+	i := strings.Index(xmlStr, marker)
+	if i < 0 {
+		return "", false
+	}
+	tagEnd := i + strings.Index(xmlStr[i:], ">")
+	if xmlStr[tagEnd-1] == '/' {
+		// 没有子元素的 <p:cTn .../>
+		return xmlStr[:tagEnd-1] + `><p:childTnLst>` + head + tail + `</p:childTnLst></p:cTn>` + xmlStr[tagEnd+1:], true
+	}
+
+	rest := xmlStr[tagEnd+1:]
+	cTnEnd := strings.Index(rest, `</p:cTn>`)
+	list := strings.Index(rest, `<p:childTnLst`)
+	if list < 0 || (cTnEnd >= 0 && cTnEnd < list) {
+		// 没有子节点列表，放在 p:cTn 的末尾
+		pos := tagEnd + 1 + cTnEnd
+		return xmlStr[:pos] + `<p:childTnLst>` + head + tail + `</p:childTnLst>` + xmlStr[pos:], true
+	}
+	list += tagEnd + 1
+	if strings.HasPrefix(xmlStr[list:], `<p:childTnLst/>`) {
+		return xmlStr[:list] + `<p:childTnLst>` + head + tail + `</p:childTnLst>` + xmlStr[list+len(`<p:childTnLst/>`):], true
+	}
+
+	// 找到与之匹配的结束标签
+	open := list + len(`<p:childTnLst>`)
+	depth := 1
+	pos := open
+	for depth > 0 {
+		next := strings.Index(xmlStr[pos:], `p:childTnLst>`)
+		if next < 0 {
+			return "", false
+		}
+		pos += next
+		if xmlStr[pos-1] == '/' {
+			depth--
+		} else {
+			depth++
+		}
+		pos += len(`p:childTnLst>`)
+	}
+	closeTag := pos - len(`</p:childTnLst>`)
+	return xmlStr[:open] + head + xmlStr[open:closeTag] + tail + xmlStr[closeTag:], true
+}
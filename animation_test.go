@@ -0,0 +1,221 @@
+package genppt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestAnimationEffects 测试各种动画效果
+func TestAnimationEffects(t *testing.T) {
+	tests := []struct {
+		name     string
+		anim     AnimationOptions
+		contains []string
+	}{
+		{"出现", AnimationOptions{Effect: AnimationAppear}, []string{
+			`presetID="1" presetClass="entr" presetSubtype="0" fill="hold" grpId="0" nodeType="clickEffect"`,
+			`<p:strVal val="visible"/>`,
+		}},
+		{"淡化", AnimationOptions{Effect: AnimationFade, Duration: 1}, []string{
+			`presetID="10" presetClass="entr"`,
+			`<p:animEffect transition="in" filter="fade"><p:cBhvr><p:cTn id="7" dur="1000"/>`,
+		}},
+		{"从左侧飞入", AnimationOptions{Effect: AnimationFlyIn, Direction: DirectionLeft}, []string{
+			`presetID="2" presetClass="entr" presetSubtype="8"`,
+			`<p:strVal val="0-#ppt_w/2"/>`,
+			`<p:attrName>ppt_y</p:attrName>`,
+		}},
+		{"缩放", AnimationOptions{Effect: AnimationZoom}, []string{
+			`presetID="53" presetClass="entr" presetSubtype="16"`,
+			`<p:attrName>ppt_w</p:attrName>`,
+		}},
+		{"擦除", AnimationOptions{Effect: AnimationWipe, Direction: DirectionRight}, []string{
+			`presetSubtype="2"`,
+			`filter="wipe(left)"`,
+		}},
+		{"脉冲", AnimationOptions{Effect: AnimationPulse}, []string{
+			`presetID="26" presetClass="emph"`,
+			`autoRev="1"`,
+			`<p:by x="105000" y="105000"/>`,
+		}},
+		{"淡出", AnimationOptions{Effect: AnimationFade, Exit: true}, []string{
+			`presetClass="exit"`,
+			`<p:animEffect transition="out" filter="fade">`,
+			`<p:cond delay="499"/>`,
+			`<p:strVal val="hidden"/>`,
+		}},
+		{"延迟", AnimationOptions{Effect: AnimationFade, Delay: 1.5}, []string{
+			`nodeType="clickEffect"><p:stCondLst><p:cond delay="1500"/></p:stCondLst>`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anim := tt.anim
+			pres := New()
+			slide := pres.AddSlide().AddText("标题", TextOptions{Animation: &anim})
+			xmlStr := slide.generateSlide(slide.collectLinks())
+			for _, s := range tt.contains {
+				if !strings.Contains(xmlStr, s) {
+					t.Errorf("应该包含 %s", s)
+				}
+			}
+			if !strings.Contains(xmlStr, `<p:spTgt spid="2"/>`) {
+				t.Error("动画目标应该是文本框")
+			}
+			if !strings.Contains(xmlStr, `<p:bldLst><p:bldP spid="2" grpId="0" animBg="1"/></p:bldLst>`) {
+				t.Error("缺少构建列表")
+			}
+		})
+	}
+}
+
+// TestAnimationTriggers 测试动画的开始方式
+func TestAnimationTriggers(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide().
+		AddShape(ShapeRect, ShapeOptions{Animation: &AnimationOptions{Effect: AnimationFade, Trigger: TriggerWithPrevious}}).
+		AddShape(ShapeRect, ShapeOptions{Animation: &AnimationOptions{Effect: AnimationFade, Trigger: TriggerAfterPrevious}}).
+		AddShape(ShapeRect, ShapeOptions{Animation: &AnimationOptions{Effect: AnimationFade}}).
+		AddShape(ShapeRect, ShapeOptions{Animation: &AnimationOptions{Effect: AnimationFade, Trigger: TriggerWithPrevious}})
+	xmlStr := slide.generateTiming()
+
+	// 第一组随幻灯片自动开始，第二组单击开始
+	if strings.Count(xmlStr, `<p:cond delay="indefinite"/>`) != 2 {
+		t.Error("应该有两个动画组")
+	}
+	if strings.Count(xmlStr, `<p:cond evt="onBegin" delay="0"><p:tn val="2"/></p:cond>`) != 1 {
+		t.Error("第一组应该自动开始")
+	}
+	// "之后"的动画在前一个动画结束后开始
+	if !strings.Contains(xmlStr, `<p:cond delay="500"/></p:stCondLst><p:childTnLst><p:par><p:cTn id="`) {
+		t.Error("之后开始的动画应该偏移前一个动画的时长")
+	}
+	for _, nodeType := range []string{"withEffect", "afterEffect", "clickEffect"} {
+		if !strings.Contains(xmlStr, `nodeType="`+nodeType+`"`) {
+			t.Errorf("缺少 %s", nodeType)
+		}
+	}
+}
+
+// TestAnimationByParagraph 测试按段落逐条播放
+func TestAnimationByParagraph(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide().AddText("第一条\n第二条\n第三条", TextOptions{
+		Animation: &AnimationOptions{Effect: AnimationFlyIn, ByParagraph: true},
+	})
+	xmlStr := slide.generateTiming()
+
+	for i := 0; i < 3; i++ {
+		s := `<p:txEl><p:pRg st="` + itoa(i) + `" end="` + itoa(i) + `"/></p:txEl>`
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("缺少第%d段的动画", i+1)
+		}
+	}
+	if strings.Count(xmlStr, `nodeType="clickEffect"`) != 3 {
+		t.Error("每段应该单击一次")
+	}
+	if !strings.Contains(xmlStr, `<p:bldP spid="2" grpId="0" build="p"/>`) {
+		t.Error("按段落播放应该使用 build=\"p\"")
+	}
+
+	// 空段落不单独播放，占位符也可以逐段播放
+	slide = pres.AddSlide().
+		AddText("第一条\n\n  \n第四条", TextOptions{Animation: &AnimationOptions{ByParagraph: true}}).
+		SetTitle("标题").
+		SetTitleAnimation(AnimationOptions{Effect: AnimationFade}).
+		SetBody("要点一\n\n要点三").
+		SetBodyAnimation(AnimationOptions{ByParagraph: true, Trigger: TriggerAfterPrevious})
+	xmlStr = slide.generateTiming()
+	if strings.Count(xmlStr, `<p:spTgt spid="4"><p:txEl>`) != 2 || strings.Contains(xmlStr, `<p:spTgt spid="4"><p:txEl><p:pRg st="1"`) {
+		t.Error("文本框中的空段落不应该有动画")
+	}
+	if strings.Count(xmlStr, `<p:spTgt spid="3"><p:txEl>`) != 2 || !strings.Contains(xmlStr, `<p:spTgt spid="3"><p:txEl><p:pRg st="2" end="2"/>`) {
+		t.Error("正文占位符应该逐段播放并跳过空段落")
+	}
+	if !strings.Contains(xmlStr, `<p:bldP spid="2" grpId="0" animBg="1"/>`) || !strings.Contains(xmlStr, `<p:bldP spid="3" grpId="0" build="p"/>`) {
+		t.Error("占位符应该添加到构建列表")
+	}
+}
+
+// TestAnimationWithMedia 测试动画与自动播放媒体共存
+func TestAnimationWithMedia(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide().
+		AddAudio(AudioOptions{Data: []byte("audio"), AutoPlay: true}).
+		AddTable([][]TableCell{{{Text: "A"}}}, TableOptions{Animation: &AnimationOptions{Effect: AnimationAppear}}).
+		AddChart(ChartBar, []ChartSeries{{Name: "S", Labels: []string{"a"}, Values: []float64{1}}}, ChartOptions{
+			Animation: &AnimationOptions{Effect: AnimationWipe},
+		})
+	xmlStr := slide.generateTiming()
+
+	if !strings.Contains(xmlStr, `presetClass="mediacall"`) {
+		t.Error("自动播放媒体丢失")
+	}
+	if !strings.Contains(xmlStr, `<p:bldGraphic spid="3" grpId="0"><p:bldAsOne/></p:bldGraphic>`) {
+		t.Error("表格应该使用 bldGraphic")
+	}
+	if !strings.Contains(xmlStr, `<p:spTgt spid="4"/>`) {
+		t.Error("图表动画目标ID错误")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	checkWellFormed(t, readZipParts(t, data))
+}
+
+// TestAnimationMergeTiming 测试在打开的幻灯片上添加动画时保留原有动画
+func TestAnimationMergeTiming(t *testing.T) {
+	pres := New()
+	pres.AddSlide().
+		AddText("第一项", TextOptions{Animation: &AnimationOptions{Effect: AnimationFade}}).
+		AddText("第二项", TextOptions{Animation: &AnimationOptions{Effect: AnimationAppear}})
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+
+	opened, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	opened.GetSlide(0).
+		AddText("第三项", TextOptions{Animation: &AnimationOptions{Effect: AnimationWipe}}).
+		AddAudio(AudioOptions{Data: []byte("audio"), AutoPlay: true})
+	out, err := opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, out)
+	checkWellFormed(t, parts)
+
+	slideXML := string(parts["ppt/slides/slide1.xml"])
+	if strings.Count(slideXML, `<p:timing>`) != 1 || strings.Count(slideXML, `nodeType="tmRoot"`) != 1 || strings.Count(slideXML, `nodeType="mainSeq"`) != 1 {
+		t.Fatal("应该只有一个时间轴和主序列")
+	}
+	for _, spid := range []string{"2", "3", "4"} {
+		if !strings.Contains(slideXML, `<p:spTgt spid="`+spid+`"`) {
+			t.Errorf("时间轴应该包含对象 %s 的动画", spid)
+		}
+		if !strings.Contains(slideXML, `<p:bldP spid="`+spid+`"`) {
+			t.Errorf("构建列表应该包含对象 %s", spid)
+		}
+	}
+	if strings.Count(slideXML, `nodeType="clickEffect"`) != 3 || !strings.Contains(slideXML, `presetClass="mediacall"`) {
+		t.Error("新的动画应该追加在原有动画之后")
+	}
+	if strings.Index(slideXML, `filter="fade"`) > strings.Index(slideXML, `filter="wipe(`) {
+		t.Error("原有动画应该在新动画之前")
+	}
+
+	ids := make(map[string]bool)
+	for _, m := range timeNodeIDRe.FindAllStringSubmatch(slideXML, -1) {
+		if ids[m[1]] {
+			t.Errorf("时间节点ID %s 重复", m[1])
+		}
+		ids[m[1]] = true
+	}
+}
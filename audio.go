@@ -7,15 +7,16 @@ import (
 
 // AudioOptions 音频选项
 type AudioOptions struct {
-	X        float64 // X坐标（英寸），音频图标位置
-	Y        float64 // Y坐标（英寸），音频图标位置
-	Width    float64 // 宽度（英寸），音频图标大小
-	Height   float64 // 高度（英寸），音频图标大小
-	Path     string  // 本地音频文件路径
-	Data     []byte  // 音频数据（与Path二选一）
-	AutoPlay bool    // 是否自动播放
-	Loop     bool    // 是否循环播放
-	Hidden   bool    // 是否隐藏音频图标（用于背景音乐）
+	X         float64           // X坐标（英寸），音频图标位置
+	Y         float64           // Y坐标（英寸），音频图标位置
+	Width     float64           // 宽度（英寸），音频图标大小
+	Height    float64           // 高度（英寸），音频图标大小
+	Path      string            // 本地音频文件路径
+	Data      []byte            // 音频数据（与Path二选一）
+	AutoPlay  bool              // 是否自动播放
+	Loop      bool              // 是否循环播放
+	Hidden    bool              // 是否隐藏音频图标（用于背景音乐）
+	Animation *AnimationOptions // 动画
 }
 
 // audioObject 音频对象
@@ -61,8 +62,8 @@ func (s *Slide) AddAudio(opts AudioOptions) *Slide {
 	}
 
 	// 生成媒体文件关系ID
-	mediaIndex := len(s.presentation.mediaFiles) + 1
-	rID := "rId" + itoa(mediaIndex+400) // 使用较大的rId避免冲突
+	mediaIndex := s.presentation.nextMediaIndex()
+	rID := mediaRelID(mediaIndex)
 	mediaPath := "ppt/media/audio" + itoa(mediaIndex) + "." + ext
 
 	// 添加到演示文稿的媒体文件列表
@@ -68,7 +68,7 @@ func TestAudioGeneration(t *testing.T) {
 	})
 
 	// 生成幻灯片XML
-	xml := slide.generateSlide()
+	xml := slide.generateSlide(slide.collectLinks())
 
 	// 检查是否包含音频元素
 	if !strings.Contains(xml, "audioFile") {
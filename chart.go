@@ -1,7 +1,10 @@
 package genppt
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 )
 
 // ChartType 图表类型
@@ -12,48 +15,104 @@ const (
 	ChartBar ChartType = "bar"
 	// ChartBarStacked 堆叠柱状图
 	ChartBarStacked ChartType = "barStacked"
+	// ChartBarPercentStacked 百分比堆叠柱状图
+	ChartBarPercentStacked ChartType = "barPercentStacked"
 	// ChartBar3D 3D柱状图
 	ChartBar3D ChartType = "bar3D"
+	// ChartBarHorizontal 条形图（横向柱状图）
+	ChartBarHorizontal ChartType = "barHorizontal"
+	// ChartBarHorizontalStacked 堆叠条形图
+	ChartBarHorizontalStacked ChartType = "barHorizontalStacked"
+	// ChartBarHorizontalPercentStacked 百分比堆叠条形图
+	ChartBarHorizontalPercentStacked ChartType = "barHorizontalPercentStacked"
 	// ChartLine 折线图
 	ChartLine ChartType = "line"
 	// ChartLineSmooth 平滑折线图
 	ChartLineSmooth ChartType = "lineSmooth"
+	// ChartLineStacked 堆叠折线图
+	ChartLineStacked ChartType = "lineStacked"
+	// ChartLinePercentStacked 百分比堆叠折线图
+	ChartLinePercentStacked ChartType = "linePercentStacked"
 	// ChartPie 饼图
 	ChartPie ChartType = "pie"
 	// ChartPie3D 3D饼图
 	ChartPie3D ChartType = "pie3D"
-	// ChartDoughnut 环形图
+	// ChartDoughnut 环形图，多个系列时每个系列为一个圆环
 	ChartDoughnut ChartType = "doughnut"
 	// ChartArea 面积图
 	ChartArea ChartType = "area"
-	// ChartScatter 散点图
+	// ChartAreaStacked 堆叠面积图
+	ChartAreaStacked ChartType = "areaStacked"
+	// ChartAreaPercentStacked 百分比堆叠面积图
+	ChartAreaPercentStacked ChartType = "areaPercentStacked"
+	// ChartRadar 雷达图（带标记点的折线）
+	ChartRadar ChartType = "radar"
+	// ChartRadarFilled 填充雷达图
+	ChartRadarFilled ChartType = "radarFilled"
+	// ChartStockHLC 股价图：需要3个系列，依次为最高价、最低价、收盘价
+	ChartStockHLC ChartType = "stockHLC"
+	// ChartStockOHLC 股价图（K线）：需要4个系列，依次为开盘价、最高价、最低价、收盘价
+	ChartStockOHLC ChartType = "stockOHLC"
+	// ChartScatter 散点图（仅标记点）
 	ChartScatter ChartType = "scatter"
+	// ChartScatterLine 带直线和标记点的散点图
+	ChartScatterLine ChartType = "scatterLine"
+	// ChartScatterSmooth 带平滑线和标记点的散点图
+	ChartScatterSmooth ChartType = "scatterSmooth"
+	// ChartBubble 气泡图
+	ChartBubble ChartType = "bubble"
 )
 
 // ChartOptions 图表选项
 type ChartOptions struct {
-	X                float64  // X坐标（英寸）
-	Y                float64  // Y坐标（英寸）
-	Width            float64  // 宽度（英寸）
-	Height           float64  // 高度（英寸）
-	Title            string   // 图表标题
-	ShowTitle        bool     // 是否显示标题
-	ShowLegend       bool     // 是否显示图例
-	LegendPos        string   // 图例位置: "r"(右), "l"(左), "t"(上), "b"(下)
-	ShowValues       bool     // 是否显示数据标签
-	ShowCategoryAxis bool     // 是否显示类别轴
-	ShowValueAxis    bool     // 是否显示数值轴
-	BarGapWidth      int      // 柱间距百分比
-	HoleSize         int      // 环形图空心大小百分比(0-90)
-	Colors           []string // 自定义颜色列表
+	X                float64           // X坐标（英寸）
+	Y                float64           // Y坐标（英寸）
+	Width            float64           // 宽度（英寸）
+	Height           float64           // 高度（英寸）
+	Title            string            // 图表标题
+	ShowTitle        bool              // 是否显示标题
+	ShowLegend       bool              // 是否显示图例
+	LegendPos        string            // 图例位置: "r"(右), "l"(左), "t"(上), "b"(下)
+	ShowValues       bool              // 是否显示数据标签
+	ShowCategoryAxis bool              // 是否显示类别轴
+	ShowValueAxis    bool              // 是否显示数值轴
+	BarGapWidth      int               // 柱间距百分比
+	HoleSize         int               // 环形图空心大小百分比(0-90)
+	Colors           []string          // 自定义颜色列表
+	LabelFormat      string            // 数据标签的数字格式，如 "#,##0"、"0.0%"（饼图的百分比标签也使用此格式）
+	PercentOnly      bool              // 饼图、环形图的数据标签只显示百分比
+	UpColor          string            // K线图上涨（收盘高于开盘）的颜色，默认红色
+	DownColor        string            // K线图下跌的颜色，默认绿色
+	CategoryAxis     AxisOptions       // 类别轴（散点图和气泡图为X轴）
+	ValueAxis        AxisOptions       // 数值轴
+	SecondaryAxis    AxisOptions       // 次数值轴（组合图）
+	Style            *ChartStyle       // 图表样式，为空时使用 DefaultChartStyle
+	ReferenceLines   []ReferenceLine   // 水平参考线（目标线）
+	Animation        *AnimationOptions // 动画
 }
 
 // ChartSeries 图表数据系列
 type ChartSeries struct {
-	Name   string    // 系列名称
-	Labels []string  // 类别标签
-	Values []float64 // 数据值
-	Color  string    // 系列颜色（可选）
+	Name    string      // 系列名称
+	Labels  []string    // 类别标签
+	Dates   []time.Time // 日期类别，设置后类别轴为按时间间隔排列的日期轴（代替 Labels）
+	Values  []float64   // 数据值（散点图和气泡图中为Y值）
+	XValues []float64   // X值（散点图和气泡图），为空时使用 1、2、3...
+	Sizes   []float64   // 气泡大小（气泡图）
+	Color   string      // 系列颜色（可选）
+
+	// 数字格式，如 "0.0%"、"#,##0"、"¥#,##0.00"，用于数据和该系列的数据标签
+	NumberFormat string
+
+	// 组合图：柱状图、折线图、面积图的系列可以使用不同的图表类型和坐标轴
+	Type          ChartType // 系列的图表类型，为空时使用图表类型
+	SecondaryAxis bool      // 使用右侧的次数值轴
+
+	Trendlines []Trendline // 趋势线
+	ErrorBars  *ErrorBars  // 误差线
+	Points     []DataPoint // 单个数据点的颜色、图案、分离和标签
+
+	reference *ReferenceLine // 由参考线生成的系列
 }
 
 // DefaultChartOptions 返回默认图表选项
@@ -72,24 +131,31 @@ func DefaultChartOptions() ChartOptions {
 		BarGapWidth:      150,
 		HoleSize:         50,
 		Colors: []string{
-			"4472C4", "ED7D31", "A5A5A5", "FFC000",
-			"5B9BD5", "70AD47", "264478", "9E480E",
+			"accent1", "accent2", "accent3",
+			"accent4", "accent5", "accent6",
 		},
 	}
 }
 
 // chartObject 图表对象
 type chartObject struct {
-	chartType ChartType
-	series    []ChartSeries
-	options   ChartOptions
-	chartIdx  int // 图表索引
+	chartType   ChartType
+	series      []ChartSeries
+	options     ChartOptions
+	chartIdx    int // 图表索引
+	workbookIdx int // 嵌入工作簿的序号
 }
 
 func (c *chartObject) getType() string { return "chart" }
 
 // AddChart 添加图表
+// 股价图的系列数量不符时不添加图表，错误在 Write、WriteFile 或 ToBytes 时返回
 func (s *Slide) AddChart(chartType ChartType, series []ChartSeries, opts ChartOptions) *Slide {
+	if want := stockSeriesCount(chartType); want > 0 && len(series) != want {
+		s.presentation.setError(fmt.Errorf("股价图 %s 需要 %d 个系列，实际为 %d 个", chartType, want, len(series)))
+		return s
+	}
+
 	// 设置默认值
 	if opts.Width == 0 {
 		opts.Width = 8.0
@@ -112,6 +178,9 @@ func (s *Slide) AddChart(chartType ChartType, series []ChartSeries, opts ChartOp
 
 	// 计算图表索引
 	chartIdx := 1
+	if s.presentation.pkg != nil {
+		chartIdx += s.presentation.pkg.chartBase
+	}
 	for _, slide := range s.presentation.slides {
 		for _, obj := range slide.objects {
 			if _, ok := obj.(*chartObject); ok {
@@ -120,24 +189,36 @@ func (s *Slide) AddChart(chartType ChartType, series []ChartSeries, opts ChartOp
 		}
 	}
 
+	// 嵌入工作簿的序号，打开的文件中接在原有嵌入文件之后
+	workbookIdx := chartIdx
+	if s.presentation.pkg != nil {
+		workbookIdx += s.presentation.pkg.embeddingBase - s.presentation.pkg.chartBase
+	}
+
 	obj := &chartObject{
-		chartType: chartType,
-		series:    series,
-		options:   opts,
-		chartIdx:  chartIdx,
+		chartType:   chartType,
+		series:      withReferenceLines(chartType, series, opts.ReferenceLines),
+		options:     opts,
+		chartIdx:    chartIdx,
+		workbookIdx: workbookIdx,
 	}
 	s.objects = append(s.objects, obj)
 	return s
 }
 
-// AddBarChart 添加柱状图（便捷方法）
+// AddBarChart 添加柱状图（便捷方法），系列按名称排序；需要指定顺序时使用 AddBarChartData
 func (s *Slide) AddBarChart(title string, labels []string, data map[string][]float64, opts ChartOptions) *Slide {
-	series := make([]ChartSeries, 0)
-	for name, values := range data {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	series := make([]ChartSeries, 0, len(names))
+	for _, name := range names {
 		series = append(series, ChartSeries{
 			Name:   name,
 			Labels: labels,
-			Values: values,
+			Values: data[name],
 		})
 	}
 	opts.Title = title
@@ -145,14 +226,19 @@ func (s *Slide) AddBarChart(title string, labels []string, data map[string][]flo
 	return s.AddChart(ChartBar, series, opts)
 }
 
-// AddLineChart 添加折线图（便捷方法）
+// AddLineChart 添加折线图（便捷方法），系列按名称排序；需要指定顺序时使用 AddLineChartData
 func (s *Slide) AddLineChart(title string, labels []string, data map[string][]float64, opts ChartOptions) *Slide {
-	series := make([]ChartSeries, 0)
-	for name, values := range data {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	series := make([]ChartSeries, 0, len(names))
+	for _, name := range names {
 		series = append(series, ChartSeries{
 			Name:   name,
 			Labels: labels,
-			Values: values,
+			Values: data[name],
 		})
 	}
 	opts.Title = title
@@ -172,6 +258,11 @@ func (s *Slide) AddPieChart(title string, labels []string, values []float64, opt
 	return s.AddChart(ChartPie, series, opts)
 }
 
+// relID 返回图表在幻灯片中的关系ID，按图表序号生成
+func (c *chartObject) relID() string {
+	return "rIdC" + itoa(c.chartIdx)
+}
+
 // generateChart 生成图表XML引用
 func (s *Slide) generateChart(c *chartObject, id int) string {
 	var sb strings.Builder
@@ -181,7 +272,7 @@ func (s *Slide) generateChart(c *chartObject, id int) string {
 	cx := InchToEMU(c.options.Width)
 	cy := InchToEMU(c.options.Height)
 
-	rID := "rId" + itoa(200+c.chartIdx) // 使用较大的rId避免冲突
+	rID := c.relID()
 
 	sb.WriteString(`<p:graphicFrame>`)
 	sb.WriteString(`<p:nvGraphicFramePr>`)
@@ -226,31 +317,19 @@ func (c *chartObject) generateChartXML() string {
 	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
 	sb.WriteString(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
 	sb.WriteString(`<c:date1904 val="0"/>`)
-	sb.WriteString(`<c:lang val="zh-CN"/>`)
-	sb.WriteString(`<c:roundedCorners val="0"/>`)
+	st := c.style()
+	sb.WriteString(`<c:lang val="`)
+	sb.WriteString(st.Language)
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:roundedCorners val="`)
+	sb.WriteString(boolVal(st.RoundedCorners))
+	sb.WriteString(`"/>`)
 
 	sb.WriteString(`<c:chart>`)
 
 	// 标题
 	if c.options.ShowTitle && c.options.Title != "" {
-		sb.WriteString(`<c:title>`)
-		sb.WriteString(`<c:tx>`)
-		sb.WriteString(`<c:rich>`)
-		sb.WriteString(`<a:bodyPr/>`)
-		sb.WriteString(`<a:lstStyle/>`)
-		sb.WriteString(`<a:p>`)
-		sb.WriteString(`<a:pPr><a:defRPr sz="1400" b="0"/></a:pPr>`)
-		sb.WriteString(`<a:r>`)
-		sb.WriteString(`<a:rPr lang="zh-CN" sz="1400" b="1"/>`)
-		sb.WriteString(`<a:t>`)
-		sb.WriteString(escapeXML(c.options.Title))
-		sb.WriteString(`</a:t>`)
-		sb.WriteString(`</a:r>`)
-		sb.WriteString(`</a:p>`)
-		sb.WriteString(`</c:rich>`)
-		sb.WriteString(`</c:tx>`)
-		sb.WriteString(`<c:overlay val="0"/>`)
-		sb.WriteString(`</c:title>`)
+		sb.WriteString(c.generateTitle(st))
 	} else {
 		sb.WriteString(`<c:autoTitleDeleted val="1"/>`)
 	}
@@ -259,72 +338,58 @@ func (c *chartObject) generateChartXML() string {
 	sb.WriteString(`<c:plotArea>`)
 	sb.WriteString(`<c:layout/>`)
 
-	// 根据图表类型生成相应的图表
-	switch c.chartType {
-	case ChartBar, ChartBarStacked, ChartBar3D:
-		sb.WriteString(c.generateBarChart())
-	case ChartLine, ChartLineSmooth:
-		sb.WriteString(c.generateLineChart())
-	case ChartPie, ChartPie3D:
-		sb.WriteString(c.generatePieChart())
-	case ChartDoughnut:
-		sb.WriteString(c.generateDoughnutChart())
-	case ChartArea:
-		sb.WriteString(c.generateAreaChart())
-	default:
-		sb.WriteString(c.generateBarChart())
+	// 按图表类型和坐标轴分组生成图表
+	groups := c.chartGroups()
+	for _, g := range groups {
+		switch g.chartType {
+		case ChartBar, ChartBarStacked, ChartBarPercentStacked, ChartBar3D,
+			ChartBarHorizontal, ChartBarHorizontalStacked, ChartBarHorizontalPercentStacked:
+			sb.WriteString(c.generateBarChart(g))
+		case ChartLine, ChartLineSmooth, ChartLineStacked, ChartLinePercentStacked:
+			sb.WriteString(c.generateLineChart(g))
+		case ChartPie, ChartPie3D:
+			sb.WriteString(c.generatePieChart())
+		case ChartDoughnut:
+			sb.WriteString(c.generateDoughnutChart())
+		case ChartArea, ChartAreaStacked, ChartAreaPercentStacked:
+			sb.WriteString(c.generateAreaChart(g))
+		case ChartRadar, ChartRadarFilled:
+			sb.WriteString(c.generateRadarChart(g))
+		case ChartStockHLC, ChartStockOHLC:
+			sb.WriteString(c.generateStockChart(g))
+		case ChartScatter, ChartScatterLine, ChartScatterSmooth:
+			sb.WriteString(c.generateScatterChart(g))
+		case ChartBubble:
+			sb.WriteString(c.generateBubbleChart(g))
+		default:
+			sb.WriteString(c.generateBarChart(g))
+		}
 	}
 
 	// 坐标轴（饼图和环形图不需要）
 	if c.chartType != ChartPie && c.chartType != ChartPie3D && c.chartType != ChartDoughnut {
-		// 类别轴
-		sb.WriteString(`<c:catAx>`)
-		sb.WriteString(`<c:axId val="1"/>`)
-		sb.WriteString(`<c:scaling><c:orientation val="minMax"/></c:scaling>`)
-		sb.WriteString(`<c:delete val="0"/>`)
-		sb.WriteString(`<c:axPos val="b"/>`)
-		sb.WriteString(`<c:majorTickMark val="out"/>`)
-		sb.WriteString(`<c:minorTickMark val="none"/>`)
-		sb.WriteString(`<c:tickLblPos val="nextTo"/>`)
-		sb.WriteString(`<c:crossAx val="2"/>`)
-		sb.WriteString(`<c:crosses val="autoZero"/>`)
-		sb.WriteString(`<c:auto val="1"/>`)
-		sb.WriteString(`<c:lblAlgn val="ctr"/>`)
-		sb.WriteString(`<c:lblOffset val="100"/>`)
-		sb.WriteString(`</c:catAx>`)
-
-		// 数值轴
-		sb.WriteString(`<c:valAx>`)
-		sb.WriteString(`<c:axId val="2"/>`)
-		sb.WriteString(`<c:scaling><c:orientation val="minMax"/></c:scaling>`)
-		sb.WriteString(`<c:delete val="0"/>`)
-		sb.WriteString(`<c:axPos val="l"/>`)
-		sb.WriteString(`<c:majorGridlines/>`)
-		sb.WriteString(`<c:majorTickMark val="out"/>`)
-		sb.WriteString(`<c:minorTickMark val="none"/>`)
-		sb.WriteString(`<c:tickLblPos val="nextTo"/>`)
-		sb.WriteString(`<c:crossAx val="1"/>`)
-		sb.WriteString(`<c:crosses val="autoZero"/>`)
-		sb.WriteString(`<c:crossBetween val="between"/>`)
-		sb.WriteString(`</c:valAx>`)
+		sb.WriteString(c.generateAxes(groups))
 	}
 
+	sb.WriteString(chartShapeProps(st.PlotBackground, st.PlotBorderColor, st.PlotBorderWidth))
 	sb.WriteString(`</c:plotArea>`)
 
 	// 图例
 	if c.options.ShowLegend {
-		sb.WriteString(`<c:legend>`)
-		sb.WriteString(`<c:legendPos val="`)
-		sb.WriteString(c.options.LegendPos)
-		sb.WriteString(`"/>`)
-		sb.WriteString(`<c:overlay val="0"/>`)
-		sb.WriteString(`</c:legend>`)
+		sb.WriteString(c.generateLegend(st))
 	}
 
 	sb.WriteString(`<c:plotVisOnly val="1"/>`)
 	sb.WriteString(`<c:dispBlanksAs val="gap"/>`)
 	sb.WriteString(`</c:chart>`)
 
+	// 图表区格式和所有文字的默认格式
+	sb.WriteString(chartShapeProps(st.Background, st.BorderColor, st.BorderWidth))
+	sb.WriteString(chartTextProps(st.FontFace, st.FontSize, st.FontColor, st.Language))
+
+	// 嵌入的工作簿，用于在 PowerPoint 中编辑数据
+	sb.WriteString(`<c:externalData r:id="rId1"><c:autoUpdate val="0"/></c:externalData>`)
+
 	sb.WriteString(`<c:printSettings>`)
 	sb.WriteString(`<c:headerFooter/>`)
 	sb.WriteString(`<c:pageMargins b="0.75" l="0.7" r="0.7" t="0.75" header="0.3" footer="0.3"/>`)
@@ -336,79 +401,183 @@ func (c *chartObject) generateChartXML() string {
 	return sb.String()
 }
 
+// isXY 是否为散点图或气泡图（两个数值轴）
+func (c *chartObject) isXY() bool {
+	switch c.chartType {
+	case ChartScatter, ChartScatterLine, ChartScatterSmooth, ChartBubble:
+		return true
+	}
+	return false
+}
+
+// stockSeriesCount 返回股价图需要的系列数量，其他图表类型返回0
+func stockSeriesCount(chartType ChartType) int {
+	switch chartType {
+	case ChartStockHLC:
+		return 3
+	case ChartStockOHLC:
+		return 4
+	}
+	return 0
+}
+
+// chartGroup 绘图区中的一组图表：图表类型和坐标轴相同的系列
+type chartGroup struct {
+	chartType ChartType
+	series    []int // 系列在 c.series 中的序号
+	secondary bool  // 使用次坐标轴
+}
+
+// writeAxisIDs 写入图表组使用的坐标轴ID：主坐标轴为1、2，次坐标轴为3、4
+func (g chartGroup) writeAxisIDs(sb *strings.Builder) {
+	catID, valID := 1, 2
+	if g.secondary {
+		catID, valID = 3, 4
+	}
+	sb.WriteString(`<c:axId val="`)
+	sb.WriteString(itoa(catID))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:axId val="`)
+	sb.WriteString(itoa(valID))
+	sb.WriteString(`"/>`)
+}
+
+// isHorizontal 是否为条形图（类别轴在左侧，数值轴在底部）
+func (c *chartObject) isHorizontal() bool {
+	switch c.chartType {
+	case ChartBarHorizontal, ChartBarHorizontalStacked, ChartBarHorizontalPercentStacked:
+		return true
+	}
+	return false
+}
+
+// chartGrouping 返回柱状图、折线图、面积图的分组方式 c:grouping
+func chartGrouping(chartType ChartType) string {
+	switch chartType {
+	case ChartBarStacked, ChartBarHorizontalStacked, ChartLineStacked, ChartAreaStacked:
+		return "stacked"
+	case ChartBarPercentStacked, ChartBarHorizontalPercentStacked, ChartLinePercentStacked, ChartAreaPercentStacked:
+		return "percentStacked"
+	case ChartBar, ChartBar3D, ChartBarHorizontal:
+		return "clustered"
+	}
+	return "standard"
+}
+
+// isComboType 图表类型是否可以用于组合图（使用类别轴的柱状图、折线图、面积图，条形图除外）
+func isComboType(chartType ChartType) bool {
+	switch chartType {
+	case ChartBar, ChartBarStacked, ChartBarPercentStacked, ChartBar3D,
+		ChartLine, ChartLineSmooth, ChartLineStacked, ChartLinePercentStacked,
+		ChartArea, ChartAreaStacked, ChartAreaPercentStacked:
+		return true
+	}
+	return false
+}
+
+// chartGroups 按系列的图表类型和坐标轴分组，主坐标轴的组在前
+// 饼图、散点图等不支持组合，所有系列为一组
+func (c *chartObject) chartGroups() []chartGroup {
+	if !isComboType(c.chartType) {
+		series := make([]int, len(c.series))
+		for i := range series {
+			series[i] = i
+		}
+		return []chartGroup{{chartType: c.chartType, series: series}}
+	}
+
+	var primary, secondary []chartGroup
+	for i, series := range c.series {
+		chartType := c.chartType
+		if isComboType(series.Type) {
+			chartType = series.Type
+		}
+		groups := &primary
+		if series.SecondaryAxis {
+			groups = &secondary
+		}
+		found := false
+		for j := range *groups {
+			if (*groups)[j].chartType == chartType {
+				(*groups)[j].series = append((*groups)[j].series, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			*groups = append(*groups, chartGroup{chartType: chartType, series: []int{i}, secondary: series.SecondaryAxis})
+		}
+	}
+	if len(primary) == 0 && len(secondary) == 0 {
+		return []chartGroup{{chartType: c.chartType}}
+	}
+	return append(primary, secondary...)
+}
+
 // generateBarChart 生成柱状图XML
-func (c *chartObject) generateBarChart() string {
+func (c *chartObject) generateBarChart(g chartGroup) string {
 	var sb strings.Builder
 
 	sb.WriteString(`<c:barChart>`)
 
 	// 柱状图方向和分组
-	sb.WriteString(`<c:barDir val="col"/>`)
-	if c.chartType == ChartBarStacked {
-		sb.WriteString(`<c:grouping val="stacked"/>`)
+	if c.isHorizontal() {
+		sb.WriteString(`<c:barDir val="bar"/>`)
 	} else {
-		sb.WriteString(`<c:grouping val="clustered"/>`)
+		sb.WriteString(`<c:barDir val="col"/>`)
 	}
+	grouping := chartGrouping(g.chartType)
+	sb.WriteString(`<c:grouping val="`)
+	sb.WriteString(grouping)
+	sb.WriteString(`"/>`)
 	sb.WriteString(`<c:varyColors val="0"/>`)
 
 	// 数据系列
-	for i, series := range c.series {
-		sb.WriteString(c.generateSeries(i, series, "bar"))
+	for _, i := range g.series {
+		sb.WriteString(c.generateSeries(i, c.series[i], "bar"))
 	}
 
 	// 数据标签
-	if c.options.ShowValues {
-		sb.WriteString(`<c:dLbls>`)
-		sb.WriteString(`<c:showLegendKey val="0"/>`)
-		sb.WriteString(`<c:showVal val="1"/>`)
-		sb.WriteString(`<c:showCatName val="0"/>`)
-		sb.WriteString(`<c:showSerName val="0"/>`)
-		sb.WriteString(`<c:showPercent val="0"/>`)
-		sb.WriteString(`</c:dLbls>`)
-	}
+	c.writeGroupLabels(&sb)
 
 	sb.WriteString(`<c:gapWidth val="`)
 	sb.WriteString(itoa(c.options.BarGapWidth))
 	sb.WriteString(`"/>`)
-	sb.WriteString(`<c:axId val="1"/>`)
-	sb.WriteString(`<c:axId val="2"/>`)
+	// 堆叠的柱子需要完全重叠
+	if grouping != "clustered" {
+		sb.WriteString(`<c:overlap val="100"/>`)
+	}
+	g.writeAxisIDs(&sb)
 	sb.WriteString(`</c:barChart>`)
 
 	return sb.String()
 }
 
 // generateLineChart 生成折线图XML
-func (c *chartObject) generateLineChart() string {
+func (c *chartObject) generateLineChart(g chartGroup) string {
 	var sb strings.Builder
 
 	sb.WriteString(`<c:lineChart>`)
-	sb.WriteString(`<c:grouping val="standard"/>`)
+	sb.WriteString(`<c:grouping val="`)
+	sb.WriteString(chartGrouping(g.chartType))
+	sb.WriteString(`"/>`)
 	sb.WriteString(`<c:varyColors val="0"/>`)
 
 	// 数据系列
-	for i, series := range c.series {
-		sb.WriteString(c.generateSeries(i, series, "line"))
+	for _, i := range g.series {
+		sb.WriteString(c.generateSeries(i, c.series[i], "line"))
 	}
 
 	// 数据标签
-	if c.options.ShowValues {
-		sb.WriteString(`<c:dLbls>`)
-		sb.WriteString(`<c:showLegendKey val="0"/>`)
-		sb.WriteString(`<c:showVal val="1"/>`)
-		sb.WriteString(`<c:showCatName val="0"/>`)
-		sb.WriteString(`<c:showSerName val="0"/>`)
-		sb.WriteString(`<c:showPercent val="0"/>`)
-		sb.WriteString(`</c:dLbls>`)
-	}
+	c.writeGroupLabels(&sb)
 
 	sb.WriteString(`<c:marker val="1"/>`)
-	if c.chartType == ChartLineSmooth {
+	if g.chartType == ChartLineSmooth {
 		sb.WriteString(`<c:smooth val="1"/>`)
 	} else {
 		sb.WriteString(`<c:smooth val="0"/>`)
 	}
-	sb.WriteString(`<c:axId val="1"/>`)
-	sb.WriteString(`<c:axId val="2"/>`)
+	g.writeAxisIDs(&sb)
 	sb.WriteString(`</c:lineChart>`)
 
 	return sb.String()
@@ -431,18 +600,7 @@ func (c *chartObject) generatePieChart() string {
 	}
 
 	// 数据标签
-	sb.WriteString(`<c:dLbls>`)
-	sb.WriteString(`<c:showLegendKey val="0"/>`)
-	if c.options.ShowValues {
-		sb.WriteString(`<c:showVal val="1"/>`)
-	} else {
-		sb.WriteString(`<c:showVal val="0"/>`)
-	}
-	sb.WriteString(`<c:showCatName val="1"/>`)
-	sb.WriteString(`<c:showSerName val="0"/>`)
-	sb.WriteString(`<c:showPercent val="1"/>`)
-	sb.WriteString(`<c:showLeaderLines val="1"/>`)
-	sb.WriteString(`</c:dLbls>`)
+	c.writeGroupLabels(&sb)
 
 	if c.chartType == ChartPie3D {
 		sb.WriteString(`</c:pie3DChart>`)
@@ -460,19 +618,13 @@ func (c *chartObject) generateDoughnutChart() string {
 	sb.WriteString(`<c:doughnutChart>`)
 	sb.WriteString(`<c:varyColors val="1"/>`)
 
-	// 只使用第一个系列
-	if len(c.series) > 0 {
-		sb.WriteString(c.generateSeries(0, c.series[0], "pie"))
+	// 每个系列一个圆环，第一个系列在最内层
+	for i, series := range c.series {
+		sb.WriteString(c.generateSeries(i, series, "pie"))
 	}
 
 	// 数据标签
-	sb.WriteString(`<c:dLbls>`)
-	sb.WriteString(`<c:showLegendKey val="0"/>`)
-	sb.WriteString(`<c:showVal val="0"/>`)
-	sb.WriteString(`<c:showCatName val="1"/>`)
-	sb.WriteString(`<c:showSerName val="0"/>`)
-	sb.WriteString(`<c:showPercent val="1"/>`)
-	sb.WriteString(`</c:dLbls>`)
+	c.writeGroupLabels(&sb)
 
 	sb.WriteString(`<c:firstSliceAng val="0"/>`)
 	sb.WriteString(`<c:holeSize val="`)
@@ -484,25 +636,413 @@ func (c *chartObject) generateDoughnutChart() string {
 }
 
 // generateAreaChart 生成面积图XML
-func (c *chartObject) generateAreaChart() string {
+func (c *chartObject) generateAreaChart(g chartGroup) string {
 	var sb strings.Builder
 
 	sb.WriteString(`<c:areaChart>`)
-	sb.WriteString(`<c:grouping val="standard"/>`)
+	sb.WriteString(`<c:grouping val="`)
+	sb.WriteString(chartGrouping(g.chartType))
+	sb.WriteString(`"/>`)
 	sb.WriteString(`<c:varyColors val="0"/>`)
 
 	// 数据系列
-	for i, series := range c.series {
-		sb.WriteString(c.generateSeries(i, series, "area"))
+	for _, i := range g.series {
+		sb.WriteString(c.generateSeries(i, c.series[i], "area"))
 	}
 
-	sb.WriteString(`<c:axId val="1"/>`)
-	sb.WriteString(`<c:axId val="2"/>`)
+	// 数据标签
+	c.writeGroupLabels(&sb)
+
+	g.writeAxisIDs(&sb)
 	sb.WriteString(`</c:areaChart>`)
 
 	return sb.String()
 }
 
+// generateRadarChart 生成雷达图XML
+func (c *chartObject) generateRadarChart(g chartGroup) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<c:radarChart>`)
+	kind := "line"
+	if g.chartType == ChartRadarFilled {
+		sb.WriteString(`<c:radarStyle val="filled"/>`)
+		kind = "area"
+	} else {
+		sb.WriteString(`<c:radarStyle val="marker"/>`)
+	}
+	sb.WriteString(`<c:varyColors val="0"/>`)
+
+	// 数据系列
+	for _, i := range g.series {
+		sb.WriteString(c.generateSeries(i, c.series[i], kind))
+	}
+
+	// 数据标签
+	c.writeGroupLabels(&sb)
+
+	g.writeAxisIDs(&sb)
+	sb.WriteString(`</c:radarChart>`)
+
+	return sb.String()
+}
+
+// generateStockChart 生成股价图XML
+// 系列本身不画线，由高低线连接最高价和最低价；K线图用涨跌柱表示开盘价到收盘价
+func (c *chartObject) generateStockChart(g chartGroup) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<c:stockChart>`)
+
+	// 数据系列
+	for _, i := range g.series {
+		sb.WriteString(c.generateSeries(i, c.series[i], "stock"))
+	}
+
+	// 数据标签
+	c.writeGroupLabels(&sb)
+
+	// 高低线
+	sb.WriteString(`<c:hiLowLines>`)
+	sb.WriteString(`<c:spPr><a:ln w="9525">`)
+	sb.WriteString(solidFill("595959"))
+	sb.WriteString(`</a:ln></c:spPr>`)
+	sb.WriteString(`</c:hiLowLines>`)
+
+	// 涨跌柱
+	if g.chartType == ChartStockOHLC {
+		sb.WriteString(`<c:upDownBars>`)
+		sb.WriteString(`<c:gapWidth val="`)
+		sb.WriteString(itoa(c.options.BarGapWidth))
+		sb.WriteString(`"/>`)
+		sb.WriteString(`<c:upBars><c:spPr>`)
+		sb.WriteString(solidFill(defaultIfEmpty(c.options.UpColor, "C00000")))
+		sb.WriteString(`</c:spPr></c:upBars>`)
+		sb.WriteString(`<c:downBars><c:spPr>`)
+		sb.WriteString(solidFill(defaultIfEmpty(c.options.DownColor, "00B050")))
+		sb.WriteString(`</c:spPr></c:downBars>`)
+		sb.WriteString(`</c:upDownBars>`)
+	}
+
+	g.writeAxisIDs(&sb)
+	sb.WriteString(`</c:stockChart>`)
+
+	return sb.String()
+}
+
+// generateScatterChart 生成散点图XML
+func (c *chartObject) generateScatterChart(g chartGroup) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<c:scatterChart>`)
+	sb.WriteString(`<c:scatterStyle val="lineMarker"/>`)
+	sb.WriteString(`<c:varyColors val="0"/>`)
+
+	// 数据系列
+	for _, i := range g.series {
+		sb.WriteString(c.generateXYSeries(i, c.series[i]))
+	}
+
+	// 数据标签
+	c.writeGroupLabels(&sb)
+
+	g.writeAxisIDs(&sb)
+	sb.WriteString(`</c:scatterChart>`)
+
+	return sb.String()
+}
+
+// generateBubbleChart 生成气泡图XML
+func (c *chartObject) generateBubbleChart(g chartGroup) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<c:bubbleChart>`)
+	sb.WriteString(`<c:varyColors val="0"/>`)
+
+	// 数据系列
+	for _, i := range g.series {
+		sb.WriteString(c.generateXYSeries(i, c.series[i]))
+	}
+
+	// 数据标签
+	c.writeGroupLabels(&sb)
+
+	sb.WriteString(`<c:bubbleScale val="100"/>`)
+	sb.WriteString(`<c:showNegBubbles val="0"/>`)
+	g.writeAxisIDs(&sb)
+	sb.WriteString(`</c:bubbleChart>`)
+
+	return sb.String()
+}
+
+// xValues 返回系列的X值，未设置时使用 1、2、3...
+func (series ChartSeries) xValues() []float64 {
+	if len(series.XValues) > 0 {
+		return series.XValues
+	}
+	values := make([]float64, len(series.Values))
+	for i := range values {
+		values[i] = float64(i + 1)
+	}
+	return values
+}
+
+// bubbleSizes 返回气泡大小，未设置时所有气泡一样大
+func (series ChartSeries) bubbleSizes() []float64 {
+	if len(series.Sizes) > 0 {
+		return series.Sizes
+	}
+	sizes := make([]float64, len(series.Values))
+	for i := range sizes {
+		sizes[i] = 1
+	}
+	return sizes
+}
+
+// xyColumns 返回散点图/气泡图系列在工作表中的列（X值列、Y值列、大小列）
+func (c *chartObject) xyColumns(idx int) (int, int, int) {
+	width := 2
+	if c.chartType == ChartBubble {
+		width = 3
+	}
+	return idx * width, idx*width + 1, idx*width + 2
+}
+
+// generateXYSeries 生成散点图或气泡图的数据系列XML
+func (c *chartObject) generateXYSeries(idx int, series ChartSeries) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<c:ser>`)
+	sb.WriteString(`<c:idx val="`)
+	sb.WriteString(itoa(idx))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:order val="`)
+	sb.WriteString(itoa(idx))
+	sb.WriteString(`"/>`)
+
+	xCol, yCol, sizeCol := c.xyColumns(idx)
+	if series.Name != "" {
+		sb.WriteString(`<c:tx>`)
+		writeStrRef(&sb, sheetRef(yCol, 1, 1), []string{series.Name})
+		sb.WriteString(`</c:tx>`)
+	}
+
+	// 系列颜色
+	color := series.Color
+	if color == "" && idx < len(c.options.Colors) {
+		color = c.options.Colors[idx]
+	}
+	if series.reference != nil {
+		sb.WriteString(series.reference.generateSpPr())
+	} else {
+		sb.WriteString(`<c:spPr>`)
+		if c.chartType == ChartBubble {
+			if color != "" {
+				sb.WriteString(solidFill(color))
+			}
+		} else if c.chartType == ChartScatter {
+			// 仅标记点，不画线
+			sb.WriteString(`<a:ln w="28575"><a:noFill/></a:ln>`)
+		} else if color != "" {
+			sb.WriteString(`<a:ln w="28575">`)
+			sb.WriteString(solidFill(color))
+			sb.WriteString(`</a:ln>`)
+		}
+		sb.WriteString(`</c:spPr>`)
+	}
+
+	if c.chartType == ChartBubble {
+		sb.WriteString(`<c:invertIfNegative val="0"/>`)
+	} else if series.reference != nil {
+		// 参考线不显示标记点
+		sb.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
+	} else {
+		// 标记点
+		sb.WriteString(`<c:marker>`)
+		sb.WriteString(`<c:symbol val="circle"/>`)
+		sb.WriteString(`<c:size val="7"/>`)
+		if color != "" {
+			sb.WriteString(`<c:spPr>`)
+			sb.WriteString(solidFill(color))
+			sb.WriteString(`</c:spPr>`)
+		}
+		sb.WriteString(`</c:marker>`)
+	}
+
+	// 数据点格式
+	if series.reference == nil {
+		kind := "line"
+		if c.chartType == ChartBubble {
+			kind = "bubble"
+		}
+		sb.WriteString(c.generateDataPoints(series, color, kind))
+	}
+
+	// 系列的数据标签格式，参考线不显示数据标签
+	if series.reference != nil {
+		sb.WriteString(`<c:dLbls><c:delete val="1"/></c:dLbls>`)
+	} else {
+		sb.WriteString(c.generateSeriesLabels(series, false))
+	}
+
+	// 趋势线和误差线
+	sb.WriteString(c.generateSeriesAnalysis(idx, series, true))
+
+	// X值和Y值
+	xValues := series.xValues()
+	sb.WriteString(`<c:xVal>`)
+	writeNumRef(&sb, sheetRef(xCol, 2, len(xValues)+1), xValues, "")
+	sb.WriteString(`</c:xVal>`)
+	sb.WriteString(`<c:yVal>`)
+	writeNumRef(&sb, sheetRef(yCol, 2, len(series.Values)+1), series.Values, series.NumberFormat)
+	sb.WriteString(`</c:yVal>`)
+
+	if c.chartType == ChartBubble {
+		sizes := series.bubbleSizes()
+		sb.WriteString(`<c:bubbleSize>`)
+		writeNumRef(&sb, sheetRef(sizeCol, 2, len(sizes)+1), sizes, "")
+		sb.WriteString(`</c:bubbleSize>`)
+		sb.WriteString(`<c:bubble3D val="0"/>`)
+	} else if c.chartType == ChartScatterSmooth {
+		sb.WriteString(`<c:smooth val="1"/>`)
+	} else {
+		sb.WriteString(`<c:smooth val="0"/>`)
+	}
+
+	sb.WriteString(`</c:ser>`)
+
+	return sb.String()
+}
+
+// labelNumFmt 生成数据标签的数字格式 c:numFmt，未设置格式时返回空字符串
+func labelNumFmt(format string) string {
+	if format == "" {
+		return ""
+	}
+	return `<c:numFmt formatCode="` + escapeXML(format) + `" sourceLinked="0"/>`
+}
+
+// writeGroupLabels 写入图表组的数据标签 c:dLbls
+// 饼图、环形图总是显示标签，其他图表只在显示数据标签时写入
+func (c *chartObject) writeGroupLabels(sb *strings.Builder) {
+	pie := c.chartType == ChartPie || c.chartType == ChartPie3D || c.chartType == ChartDoughnut
+	if !pie && !c.options.ShowValues {
+		return
+	}
+	sb.WriteString(`<c:dLbls>`)
+	sb.WriteString(labelNumFmt(c.options.LabelFormat))
+	if pie {
+		sb.WriteString(c.pieLabelFlags())
+	} else {
+		sb.WriteString(valueLabelFlags())
+	}
+	if pie && c.chartType != ChartDoughnut {
+		sb.WriteString(`<c:showLeaderLines val="1"/>`)
+	}
+	sb.WriteString(`</c:dLbls>`)
+}
+
+// generateSeriesLabels 生成系列的数据标签 c:dLbls
+// 在有自定义标签或数字格式的数据点，或显示数据标签且系列设置了数字格式时生成；pie 为饼图或环形图
+func (c *chartObject) generateSeriesLabels(series ChartSeries, pie bool) string {
+	pointLabels := series.hasPointLabels(pie || c.options.ShowValues)
+	if !pointLabels && (pie || !c.options.ShowValues || series.NumberFormat == "") {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<c:dLbls>`)
+	switch {
+	case pie:
+		// 其余扇区沿用饼图的标签设置
+		sb.WriteString(series.generatePointLabels(c.style().Language, c.pieLabelFlags()))
+		sb.WriteString(labelNumFmt(c.options.LabelFormat))
+		sb.WriteString(c.pieLabelFlags())
+	case c.options.ShowValues:
+		sb.WriteString(series.generatePointLabels(c.style().Language, valueLabelFlags()))
+		sb.WriteString(labelNumFmt(defaultIfEmpty(series.NumberFormat, c.options.LabelFormat)))
+		sb.WriteString(valueLabelFlags())
+	default:
+		// 只显示自定义标签
+		sb.WriteString(series.generatePointLabels(c.style().Language, ""))
+		sb.WriteString(`<c:showLegendKey val="0"/>`)
+		sb.WriteString(`<c:showVal val="0"/>`)
+		sb.WriteString(`<c:showCatName val="0"/>`)
+		sb.WriteString(`<c:showSerName val="0"/>`)
+		sb.WriteString(`<c:showPercent val="0"/>`)
+		sb.WriteString(`<c:showBubbleSize val="0"/>`)
+	}
+	sb.WriteString(`</c:dLbls>`)
+	return sb.String()
+}
+
+// pieLabelFlags 生成饼图、环形图数据标签的显示内容
+// 默认显示类别名称和百分比；PercentOnly 时只显示百分比，环形图不显示数值
+func (c *chartObject) pieLabelFlags() string {
+	var sb strings.Builder
+	sb.WriteString(`<c:showLegendKey val="0"/>`)
+	if c.options.ShowValues && !c.options.PercentOnly && c.chartType != ChartDoughnut {
+		sb.WriteString(`<c:showVal val="1"/>`)
+	} else {
+		sb.WriteString(`<c:showVal val="0"/>`)
+	}
+	if c.options.PercentOnly {
+		sb.WriteString(`<c:showCatName val="0"/>`)
+	} else {
+		sb.WriteString(`<c:showCatName val="1"/>`)
+	}
+	sb.WriteString(`<c:showSerName val="0"/>`)
+	sb.WriteString(`<c:showPercent val="1"/>`)
+	return sb.String()
+}
+
+// writeStrRef 写入引用工作表的文本数据 c:strRef
+func writeStrRef(sb *strings.Builder, ref string, values []string) {
+	sb.WriteString(`<c:strRef>`)
+	sb.WriteString(`<c:f>`)
+	sb.WriteString(ref)
+	sb.WriteString(`</c:f>`)
+	sb.WriteString(`<c:strCache>`)
+	sb.WriteString(`<c:ptCount val="`)
+	sb.WriteString(itoa(len(values)))
+	sb.WriteString(`"/>`)
+	for i, v := range values {
+		sb.WriteString(`<c:pt idx="`)
+		sb.WriteString(itoa(i))
+		sb.WriteString(`"><c:v>`)
+		sb.WriteString(escapeXML(v))
+		sb.WriteString(`</c:v></c:pt>`)
+	}
+	sb.WriteString(`</c:strCache>`)
+	sb.WriteString(`</c:strRef>`)
+}
+
+// writeNumRef 写入引用工作表的数值数据 c:numRef，NaN 和无穷大作为空白点
+func writeNumRef(sb *strings.Builder, ref string, values []float64, format string) {
+	sb.WriteString(`<c:numRef>`)
+	sb.WriteString(`<c:f>`)
+	sb.WriteString(ref)
+	sb.WriteString(`</c:f>`)
+	sb.WriteString(`<c:numCache>`)
+	sb.WriteString(`<c:formatCode>`)
+	sb.WriteString(escapeXML(defaultIfEmpty(format, "General")))
+	sb.WriteString(`</c:formatCode>`)
+	sb.WriteString(`<c:ptCount val="`)
+	sb.WriteString(itoa(len(values)))
+	sb.WriteString(`"/>`)
+	for i, val := range values {
+		if !isFinite(val) {
+			continue
+		}
+		sb.WriteString(`<c:pt idx="`)
+		sb.WriteString(itoa(i))
+		sb.WriteString(`"><c:v>`)
+		sb.WriteString(ftoa(val))
+		sb.WriteString(`</c:v></c:pt>`)
+	}
+	sb.WriteString(`</c:numCache>`)
+	sb.WriteString(`</c:numRef>`)
+}
+
 // generateSeries 生成数据系列XML
 func (c *chartObject) generateSeries(idx int, series ChartSeries, chartKind string) string {
 	var sb strings.Builder
@@ -515,12 +1055,11 @@ func (c *chartObject) generateSeries(idx int, series ChartSeries, chartKind stri
 	sb.WriteString(itoa(idx))
 	sb.WriteString(`"/>`)
 
-	// 系列名称
+	// 系列名称，引用工作表第一行
+	col := idx + 1
 	if series.Name != "" {
 		sb.WriteString(`<c:tx>`)
-		sb.WriteString(`<c:v>`)
-		sb.WriteString(escapeXML(series.Name))
-		sb.WriteString(`</c:v>`)
+		writeStrRef(&sb, sheetRef(col, 1, 1), []string{series.Name})
 		sb.WriteString(`</c:tx>`)
 	}
 
@@ -529,100 +1068,92 @@ func (c *chartObject) generateSeries(idx int, series ChartSeries, chartKind stri
 	if color == "" && idx < len(c.options.Colors) {
 		color = c.options.Colors[idx]
 	}
-	if color != "" {
+	if series.reference != nil {
+		sb.WriteString(series.reference.generateSpPr())
+	} else if chartKind == "stock" {
+		// 股价图的系列不画线
+		sb.WriteString(`<c:spPr><a:ln w="19050"><a:noFill/></a:ln></c:spPr>`)
+	} else if color != "" {
 		sb.WriteString(`<c:spPr>`)
 		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(color))
-		sb.WriteString(`"/>`)
+		sb.WriteString(colorElement(color, ""))
 		sb.WriteString(`</a:solidFill>`)
 		if chartKind == "line" {
 			sb.WriteString(`<a:ln w="28575">`)
 			sb.WriteString(`<a:solidFill>`)
-			sb.WriteString(`<a:srgbClr val="`)
-			sb.WriteString(ParseColor(color))
-			sb.WriteString(`"/>`)
+			sb.WriteString(colorElement(color, ""))
 			sb.WriteString(`</a:solidFill>`)
 			sb.WriteString(`</a:ln>`)
 		}
 		sb.WriteString(`</c:spPr>`)
 	}
 
-	// 折线图标记点
-	if chartKind == "line" {
+	// 折线图标记点，参考线不显示标记点
+	if chartKind == "line" && series.reference != nil {
+		sb.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
+	} else if chartKind == "line" {
 		sb.WriteString(`<c:marker>`)
 		sb.WriteString(`<c:symbol val="circle"/>`)
 		sb.WriteString(`<c:size val="5"/>`)
 		if color != "" {
 			sb.WriteString(`<c:spPr>`)
 			sb.WriteString(`<a:solidFill>`)
-			sb.WriteString(`<a:srgbClr val="`)
-			sb.WriteString(ParseColor(color))
-			sb.WriteString(`"/>`)
+			sb.WriteString(colorElement(color, ""))
 			sb.WriteString(`</a:solidFill>`)
 			sb.WriteString(`</c:spPr>`)
 		}
 		sb.WriteString(`</c:marker>`)
 	}
 
-	// 饼图/环形图数据点颜色
-	if chartKind == "pie" && len(series.Values) > 0 {
-		sb.WriteString(`<c:dPt>`)
-		for i := range series.Values {
-			if i < len(c.options.Colors) {
-				sb.WriteString(`<c:idx val="`)
-				sb.WriteString(itoa(i))
-				sb.WriteString(`"/>`)
-				sb.WriteString(`<c:spPr>`)
-				sb.WriteString(`<a:solidFill>`)
-				sb.WriteString(`<a:srgbClr val="`)
-				sb.WriteString(ParseColor(c.options.Colors[i]))
-				sb.WriteString(`"/>`)
-				sb.WriteString(`</a:solidFill>`)
-				sb.WriteString(`</c:spPr>`)
-			}
+	// 股价图标记点：只有 HLC 的收盘价显示为短横线
+	if chartKind == "stock" {
+		if c.chartType == ChartStockHLC && idx == 2 {
+			sb.WriteString(`<c:marker>`)
+			sb.WriteString(`<c:symbol val="dash"/>`)
+			sb.WriteString(`<c:size val="7"/>`)
+			sb.WriteString(`<c:spPr>`)
+			sb.WriteString(solidFill(defaultIfEmpty(series.Color, "595959")))
+			sb.WriteString(`</c:spPr>`)
+			sb.WriteString(`</c:marker>`)
+		} else {
+			sb.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
 		}
-		sb.WriteString(`</c:dPt>`)
+	}
+
+	// 数据点格式，饼图/环形图的每个扇区使用不同颜色
+	if chartKind != "stock" && series.reference == nil {
+		sb.WriteString(c.generateDataPoints(series, color, chartKind))
+	}
+
+	// 系列的数据标签格式，参考线不显示数据标签
+	if series.reference != nil {
+		sb.WriteString(`<c:dLbls><c:delete val="1"/></c:dLbls>`)
+	} else {
+		sb.WriteString(c.generateSeriesLabels(series, chartKind == "pie"))
+	}
+
+	// 趋势线和误差线（饼图、股价图、雷达图的系列不支持）
+	if chartKind != "pie" && chartKind != "stock" && c.chartType != ChartRadar && c.chartType != ChartRadarFilled {
+		sb.WriteString(c.generateSeriesAnalysis(idx, series, false))
 	}
 
 	// 类别数据
-	if len(series.Labels) > 0 {
+	if c.hasDates() {
+		// 日期类别为日期序列号，所有系列都引用工作表A列的同一组日期
+		dates := c.categoryDates()
 		sb.WriteString(`<c:cat>`)
-		sb.WriteString(`<c:strRef>`)
-		sb.WriteString(`<c:strCache>`)
-		sb.WriteString(`<c:ptCount val="`)
-		sb.WriteString(itoa(len(series.Labels)))
-		sb.WriteString(`"/>`)
-		for i, label := range series.Labels {
-			sb.WriteString(`<c:pt idx="`)
-			sb.WriteString(itoa(i))
-			sb.WriteString(`"><c:v>`)
-			sb.WriteString(escapeXML(label))
-			sb.WriteString(`</c:v></c:pt>`)
-		}
-		sb.WriteString(`</c:strCache>`)
-		sb.WriteString(`</c:strRef>`)
+		writeNumRef(&sb, sheetRef(0, 2, len(dates)+1), dateSerials(dates), c.dateFormat())
+		sb.WriteString(`</c:cat>`)
+	} else if len(series.Labels) > 0 {
+		sb.WriteString(`<c:cat>`)
+		writeStrRef(&sb, sheetRef(0, 2, len(series.Labels)+1), series.Labels)
 		sb.WriteString(`</c:cat>`)
 	}
 
 	// 数值数据
 	if len(series.Values) > 0 {
 		sb.WriteString(`<c:val>`)
-		sb.WriteString(`<c:numRef>`)
-		sb.WriteString(`<c:numCache>`)
-		sb.WriteString(`<c:formatCode>General</c:formatCode>`)
-		sb.WriteString(`<c:ptCount val="`)
-		sb.WriteString(itoa(len(series.Values)))
-		sb.WriteString(`"/>`)
-		for i, val := range series.Values {
-			sb.WriteString(`<c:pt idx="`)
-			sb.WriteString(itoa(i))
-			sb.WriteString(`"><c:v>`)
-			sb.WriteString(ftoa(val))
-			sb.WriteString(`</c:v></c:pt>`)
-		}
-		sb.WriteString(`</c:numCache>`)
-		sb.WriteString(`</c:numRef>`)
+		writeNumRef(&sb, sheetRef(col, 2, len(series.Values)+1), series.Values, series.NumberFormat)
 		sb.WriteString(`</c:val>`)
 	}
 
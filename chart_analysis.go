@@ -0,0 +1,306 @@
+package genppt
+
+import (
+	"strings"
+	"time"
+)
+
+// Trendline 系列趋势线，由 PowerPoint 根据系列数据计算
+// 柱状图、折线图、面积图、散点图和气泡图的系列支持趋势线
+type Trendline struct {
+	Type            TrendlineType // 趋势线类型，默认线性
+	Name            string        // 图例中显示的名称，为空时自动命名
+	Order           int           // 多项式的阶数（2-6），默认2
+	Period          int           // 移动平均的周期，默认2
+	Forward         float64       // 向前预测的周期数
+	Backward        float64       // 向后预测的周期数
+	DisplayRSquared bool          // 显示R²值
+	DisplayEquation bool          // 显示公式
+	Color           string        // 线条颜色，为空时使用系列颜色
+	Width           float64       // 线宽（磅），默认1.5
+	Style           BorderStyle   // 线型，默认点线
+}
+
+// ErrorBars 系列误差线
+// 柱状图、折线图、面积图、散点图和气泡图的系列支持误差线，散点图和气泡图为Y方向
+type ErrorBars struct {
+	Type     ErrorBarType // 误差量类型，默认固定值
+	Value    float64      // 固定值、百分比或标准偏差的倍数；百分比默认5，标准偏差默认1
+	Plus     []float64    // 自定义正偏差，每个数据点一个值
+	Minus    []float64    // 自定义负偏差，每个数据点一个值
+	NoEndCap bool         // 不显示末端线帽
+	Color    string       // 线条颜色，默认深灰色
+	Width    float64      // 线宽（磅），默认0.75
+}
+
+// ReferenceLine 水平参考线（如目标线）
+// 作为无标记点的折线系列加入图表，数据写入嵌入工作簿；只用于可组合的柱状图、折线图、面积图和散点图
+type ReferenceLine struct {
+	Value         float64     // 数值轴上的位置
+	Label         string      // 名称，显示在图例中
+	Color         string      // 颜色，默认红色
+	Width         float64     // 线宽（磅），默认1.5
+	Style         BorderStyle // 线型，默认虚线
+	SecondaryAxis bool        // 使用次数值轴（散点图不支持）
+}
+
+// withReferenceLines 返回追加了参考线系列的系列列表，不修改传入的切片
+func withReferenceLines(chartType ChartType, series []ChartSeries, lines []ReferenceLine) []ChartSeries {
+	if len(lines) == 0 {
+		return series
+	}
+	xy := chartType == ChartScatter || chartType == ChartScatterLine || chartType == ChartScatterSmooth
+	if !isComboType(chartType) && !xy {
+		return series
+	}
+
+	// 类别图表的参考线覆盖所有类别，散点图的参考线从最小X值画到最大X值
+	var labels []string
+	var dates []time.Time
+	count := 0
+	minX, maxX := 0.0, 0.0
+	first := true
+	for _, s := range series {
+		if labels == nil && len(s.Labels) > 0 {
+			labels = s.Labels
+		}
+		if dates == nil && len(s.Dates) > 0 {
+			dates = s.Dates
+		}
+		count = max(count, len(s.Values))
+		for _, x := range s.xValues() {
+			if !isFinite(x) {
+				continue
+			}
+			if first || x < minX {
+				minX = x
+			}
+			if first || x > maxX {
+				maxX = x
+			}
+			first = false
+		}
+	}
+	count = max(count, max(len(labels), len(dates)))
+
+	result := make([]ChartSeries, len(series), len(series)+len(lines))
+	copy(result, series)
+	for i := range lines {
+		line := lines[i]
+		ref := ChartSeries{Name: line.Label, reference: &line}
+		if xy {
+			ref.XValues = []float64{minX, maxX}
+			ref.Values = []float64{line.Value, line.Value}
+		} else {
+			ref.Labels = labels
+			ref.Dates = dates
+			ref.Values = make([]float64, count)
+			for j := range ref.Values {
+				ref.Values[j] = line.Value
+			}
+			ref.Type = ChartLine
+			ref.SecondaryAxis = line.SecondaryAxis
+		}
+		result = append(result, ref)
+	}
+	return result
+}
+
+// generateSpPr 生成参考线系列的线条格式
+func (r *ReferenceLine) generateSpPr() string {
+	return `<c:spPr>` + chartLine(defaultIfEmpty(r.Color, "FF0000"), defaultIfZero(r.Width, 1.5), r.Style, "dash") + `</c:spPr>`
+}
+
+// chartLine 生成图表中的线条 a:ln，style 为空时使用 defaultDash
+func chartLine(color string, width float64, style BorderStyle, defaultDash string) string {
+	var sb strings.Builder
+	sb.WriteString(`<a:ln w="`)
+	sb.WriteString(itoa(int(width * EMUPerPoint)))
+	sb.WriteString(`" cap="rnd">`)
+	if style == BorderNone {
+		sb.WriteString(`<a:noFill/>`)
+	} else {
+		if color != "" {
+			sb.WriteString(solidFill(color))
+		}
+		sb.WriteString(`<a:prstDash val="`)
+		switch style {
+		case BorderSolid:
+			sb.WriteString("solid")
+		case BorderDash:
+			sb.WriteString("dash")
+		case BorderDot:
+			sb.WriteString("sysDot")
+		default:
+			sb.WriteString(defaultDash)
+		}
+		sb.WriteString(`"/>`)
+	}
+	sb.WriteString(`</a:ln>`)
+	return sb.String()
+}
+
+// generateSeriesAnalysis 生成系列的趋势线 c:trendline 和误差线 c:errBars，xy 为散点图或气泡图
+func (c *chartObject) generateSeriesAnalysis(idx int, series ChartSeries, xy bool) string {
+	color := series.Color
+	if color == "" && idx < len(c.options.Colors) {
+		color = c.options.Colors[idx]
+	}
+	var sb strings.Builder
+	for _, tl := range series.Trendlines {
+		sb.WriteString(tl.generateXML(color))
+	}
+	if series.ErrorBars != nil {
+		sb.WriteString(series.ErrorBars.generateXML(xy))
+	}
+	return sb.String()
+}
+
+// generateXML 生成趋势线 c:trendline，seriesColor 为未设置颜色时使用的系列颜色
+func (tl Trendline) generateXML(seriesColor string) string {
+	trendType := tl.Type
+	if trendType == "" {
+		trendType = TrendlineLinear
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<c:trendline>`)
+	if tl.Name != "" {
+		sb.WriteString(`<c:name>`)
+		sb.WriteString(escapeXML(tl.Name))
+		sb.WriteString(`</c:name>`)
+	}
+	sb.WriteString(`<c:spPr>`)
+	sb.WriteString(chartLine(defaultIfEmpty(tl.Color, seriesColor), defaultIfZero(tl.Width, 1.5), tl.Style, "sysDot"))
+	sb.WriteString(`</c:spPr>`)
+	sb.WriteString(`<c:trendlineType val="`)
+	sb.WriteString(string(trendType))
+	sb.WriteString(`"/>`)
+	switch trendType {
+	case TrendlinePolynomial:
+		sb.WriteString(`<c:order val="`)
+		sb.WriteString(itoa(min(6, max(2, tl.Order))))
+		sb.WriteString(`"/>`)
+	case TrendlineMovingAverage:
+		sb.WriteString(`<c:period val="`)
+		sb.WriteString(itoa(max(2, tl.Period)))
+		sb.WriteString(`"/>`)
+	}
+	// 移动平均不能预测
+	if trendType != TrendlineMovingAverage {
+		if tl.Forward > 0 {
+			sb.WriteString(`<c:forward val="`)
+			sb.WriteString(ftoa(tl.Forward))
+			sb.WriteString(`"/>`)
+		}
+		if tl.Backward > 0 {
+			sb.WriteString(`<c:backward val="`)
+			sb.WriteString(ftoa(tl.Backward))
+			sb.WriteString(`"/>`)
+		}
+	}
+	sb.WriteString(`<c:dispRSqr val="`)
+	sb.WriteString(boolVal(tl.DisplayRSquared && trendType != TrendlineMovingAverage))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:dispEq val="`)
+	sb.WriteString(boolVal(tl.DisplayEquation && trendType != TrendlineMovingAverage))
+	sb.WriteString(`"/>`)
+	if (tl.DisplayRSquared || tl.DisplayEquation) && trendType != TrendlineMovingAverage {
+		sb.WriteString(`<c:trendlineLbl><c:numFmt formatCode="General" sourceLinked="0"/></c:trendlineLbl>`)
+	}
+	sb.WriteString(`</c:trendline>`)
+	return sb.String()
+}
+
+// generateXML 生成误差线 c:errBars，散点图和气泡图需要指定Y方向
+func (eb ErrorBars) generateXML(xy bool) string {
+	errType := eb.Type
+	if errType == "" {
+		errType = ErrorBarFixed
+	}
+
+	// 自定义误差只设置一侧时只显示该侧
+	barType := "both"
+	if errType == ErrorBarCustom {
+		if len(eb.Minus) == 0 && len(eb.Plus) > 0 {
+			barType = "plus"
+		} else if len(eb.Plus) == 0 && len(eb.Minus) > 0 {
+			barType = "minus"
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<c:errBars>`)
+	if xy {
+		sb.WriteString(`<c:errDir val="y"/>`)
+	}
+	sb.WriteString(`<c:errBarType val="`)
+	sb.WriteString(barType)
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:errValType val="`)
+	sb.WriteString(string(errType))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:noEndCap val="`)
+	sb.WriteString(boolVal(eb.NoEndCap))
+	sb.WriteString(`"/>`)
+
+	switch errType {
+	case ErrorBarCustom:
+		if len(eb.Plus) > 0 {
+			sb.WriteString(`<c:plus>`)
+			writeNumLit(&sb, eb.Plus)
+			sb.WriteString(`</c:plus>`)
+		}
+		if len(eb.Minus) > 0 {
+			sb.WriteString(`<c:minus>`)
+			writeNumLit(&sb, eb.Minus)
+			sb.WriteString(`</c:minus>`)
+		}
+	case ErrorBarStdErr:
+		// 标准误差没有误差量
+	default:
+		val := eb.Value
+		if errType == ErrorBarPercentage {
+			val = defaultIfZero(val, 5)
+		} else if errType == ErrorBarStdDev {
+			val = defaultIfZero(val, 1)
+		}
+		sb.WriteString(`<c:val val="`)
+		sb.WriteString(ftoa(val))
+		sb.WriteString(`"/>`)
+	}
+
+	sb.WriteString(`<c:spPr>`)
+	sb.WriteString(chartLine(defaultIfEmpty(eb.Color, "595959"), defaultIfZero(eb.Width, 0.75), BorderSolid, "solid"))
+	sb.WriteString(`</c:spPr>`)
+	sb.WriteString(`</c:errBars>`)
+	return sb.String()
+}
+
+// writeNumLit 写入不引用工作表的数值数据 c:numLit
+func writeNumLit(sb *strings.Builder, values []float64) {
+	sb.WriteString(`<c:numLit>`)
+	sb.WriteString(`<c:formatCode>General</c:formatCode>`)
+	sb.WriteString(`<c:ptCount val="`)
+	sb.WriteString(itoa(len(values)))
+	sb.WriteString(`"/>`)
+	for i, val := range values {
+		if !isFinite(val) {
+			continue
+		}
+		sb.WriteString(`<c:pt idx="`)
+		sb.WriteString(itoa(i))
+		sb.WriteString(`"><c:v>`)
+		sb.WriteString(ftoa(val))
+		sb.WriteString(`</c:v></c:pt>`)
+	}
+	sb.WriteString(`</c:numLit>`)
+}
+
+// boolVal 返回布尔属性值 "1" 或 "0"
+func boolVal(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,296 @@
+package genppt
+
+import (
+	"strings"
+)
+
+// AxisOptions 图表坐标轴选项
+type AxisOptions struct {
+	Title         string    // 坐标轴标题
+	Min           float64   // 最小值，0为自动（仅数值轴）
+	Max           float64   // 最大值，0为自动（仅数值轴）
+	MajorUnit     float64   // 主要刻度单位，0为自动（数值轴和日期轴）
+	MinorUnit     float64   // 次要刻度单位，0为自动（数值轴和日期轴）
+	NumberFormat  string    // 刻度标签的数字格式，如 "0%"、"#,##0.00"，日期轴如 "yyyy-mm-dd"
+	BaseUnit      TimeUnit  // 日期轴的基本单位，为空时自动
+	MajorTimeUnit TimeUnit  // 日期轴主要刻度的时间单位，与 MajorUnit 一起使用
+	MinorTimeUnit TimeUnit  // 日期轴次要刻度的时间单位，与 MinorUnit 一起使用
+	Gridlines     Gridlines // 网格线
+	Reverse       bool      // 逆序刻度
+	LogBase       float64   // 对数刻度的底数（如10），0为线性刻度（仅数值轴）
+	LabelRotation float64   // 刻度标签旋转角度（度）
+	FontFace      string    // 刻度标签字体
+	FontSize      float64   // 刻度标签字号（磅）
+	FontColor     string    // 刻度标签颜色
+	Hidden        bool      // 隐藏坐标轴
+}
+
+// chartAxis 绘图区中的一个坐标轴
+type chartAxis struct {
+	id           int
+	crossAx      int
+	valueAxis    bool   // 数值轴 c:valAx，否则为类别轴 c:catAx
+	dateAxis     bool   // 日期轴 c:dateAx（类别为日期时）
+	pos          string // 位置：b、l、r
+	crosses      string // 与另一坐标轴的交叉位置：autoZero、max
+	crossBetween string // 数值轴的交叉方式：between、midCat
+	gridlines    bool   // 默认是否显示主要网格线
+	deleted      bool   // 不显示（组合图的次类别轴）
+	lang         string // 文字的语言
+	options      AxisOptions
+}
+
+// generateAxes 生成坐标轴，散点图和气泡图的横轴也是数值轴
+// 有系列使用次坐标轴时，增加隐藏的次类别轴和右侧的次数值轴
+func (c *chartObject) generateAxes(groups []chartGroup) string {
+	var sb strings.Builder
+	lang := c.style().Language
+
+	if c.isXY() {
+		sb.WriteString(chartAxis{id: 1, lang: lang, crossAx: 2, valueAxis: true, pos: "b", crosses: "autoZero", crossBetween: "midCat", options: c.options.CategoryAxis}.generateXML())
+		sb.WriteString(chartAxis{id: 2, lang: lang, crossAx: 1, valueAxis: true, pos: "l", crosses: "autoZero", crossBetween: "midCat", gridlines: true, options: c.options.ValueAxis}.generateXML())
+		return sb.String()
+	}
+
+	// 条形图的类别轴在左侧、数值轴在底部；雷达图的类别轴默认显示网格线
+	catPos, valPos := "b", "l"
+	if c.isHorizontal() {
+		catPos, valPos = "l", "b"
+	}
+	catGridlines := c.chartType == ChartRadar || c.chartType == ChartRadarFilled
+	sb.WriteString(chartAxis{id: 1, lang: lang, crossAx: 2, dateAxis: c.hasDates(), pos: catPos, crosses: "autoZero", gridlines: catGridlines, options: c.options.CategoryAxis}.generateXML())
+	sb.WriteString(chartAxis{id: 2, lang: lang, crossAx: 1, valueAxis: true, pos: valPos, crosses: "autoZero", crossBetween: "between", gridlines: true, options: c.options.ValueAxis}.generateXML())
+
+	for _, g := range groups {
+		if g.secondary {
+			sb.WriteString(chartAxis{id: 3, lang: lang, crossAx: 4, dateAxis: c.hasDates(), pos: "b", crosses: "autoZero", deleted: true}.generateXML())
+			sb.WriteString(chartAxis{id: 4, lang: lang, crossAx: 3, valueAxis: true, pos: "r", crosses: "max", crossBetween: "between", options: c.options.SecondaryAxis}.generateXML())
+			break
+		}
+	}
+
+	return sb.String()
+}
+
+// generateXML 生成坐标轴 c:catAx、c:dateAx 或 c:valAx
+func (ax chartAxis) generateXML() string {
+	opts := ax.options
+	tag := "c:catAx"
+	if ax.valueAxis {
+		tag = "c:valAx"
+	} else if ax.dateAxis {
+		tag = "c:dateAx"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<`)
+	sb.WriteString(tag)
+	sb.WriteString(`>`)
+	sb.WriteString(`<c:axId val="`)
+	sb.WriteString(itoa(ax.id))
+	sb.WriteString(`"/>`)
+
+	// 刻度范围
+	sb.WriteString(`<c:scaling>`)
+	if ax.valueAxis && opts.LogBase > 0 {
+		sb.WriteString(`<c:logBase val="`)
+		sb.WriteString(ftoa(opts.LogBase))
+		sb.WriteString(`"/>`)
+	}
+	if opts.Reverse {
+		sb.WriteString(`<c:orientation val="maxMin"/>`)
+	} else {
+		sb.WriteString(`<c:orientation val="minMax"/>`)
+	}
+	if ax.valueAxis && opts.Max != 0 {
+		sb.WriteString(`<c:max val="`)
+		sb.WriteString(ftoa(opts.Max))
+		sb.WriteString(`"/>`)
+	}
+	if ax.valueAxis && opts.Min != 0 {
+		sb.WriteString(`<c:min val="`)
+		sb.WriteString(ftoa(opts.Min))
+		sb.WriteString(`"/>`)
+	}
+	sb.WriteString(`</c:scaling>`)
+
+	if ax.deleted || opts.Hidden {
+		sb.WriteString(`<c:delete val="1"/>`)
+	} else {
+		sb.WriteString(`<c:delete val="0"/>`)
+	}
+	sb.WriteString(`<c:axPos val="`)
+	sb.WriteString(ax.pos)
+	sb.WriteString(`"/>`)
+
+	// 网格线
+	major, minor := ax.gridlines, false
+	switch opts.Gridlines {
+	case GridlinesNone:
+		major = false
+	case GridlinesMajor:
+		major = true
+	case GridlinesMinor:
+		major, minor = false, true
+	case GridlinesBoth:
+		major, minor = true, true
+	}
+	if major {
+		sb.WriteString(`<c:majorGridlines/>`)
+	}
+	if minor {
+		sb.WriteString(`<c:minorGridlines/>`)
+	}
+
+	// 坐标轴标题，纵轴标题旋转90度
+	if opts.Title != "" {
+		rot := 0
+		if ax.pos == "l" || ax.pos == "r" {
+			rot = -5400000
+		}
+		sb.WriteString(`<c:title>`)
+		sb.WriteString(`<c:tx>`)
+		sb.WriteString(`<c:rich>`)
+		sb.WriteString(`<a:bodyPr rot="`)
+		sb.WriteString(itoa(rot))
+		sb.WriteString(`" vert="horz"/>`)
+		sb.WriteString(`<a:lstStyle/>`)
+		sb.WriteString(`<a:p>`)
+		sb.WriteString(`<a:pPr><a:defRPr b="0"/></a:pPr>`)
+		sb.WriteString(`<a:r>`)
+		sb.WriteString(`<a:rPr lang="`)
+		sb.WriteString(ax.lang)
+		sb.WriteString(`"/>`)
+		sb.WriteString(`<a:t>`)
+		sb.WriteString(escapeXML(opts.Title))
+		sb.WriteString(`</a:t>`)
+		sb.WriteString(`</a:r>`)
+		sb.WriteString(`</a:p>`)
+		sb.WriteString(`</c:rich>`)
+		sb.WriteString(`</c:tx>`)
+		sb.WriteString(`<c:overlay val="0"/>`)
+		sb.WriteString(`</c:title>`)
+	}
+
+	if opts.NumberFormat != "" {
+		sb.WriteString(`<c:numFmt formatCode="`)
+		sb.WriteString(escapeXML(opts.NumberFormat))
+		sb.WriteString(`" sourceLinked="0"/>`)
+	}
+	sb.WriteString(`<c:majorTickMark val="out"/>`)
+	sb.WriteString(`<c:minorTickMark val="none"/>`)
+	sb.WriteString(`<c:tickLblPos val="nextTo"/>`)
+
+	// 刻度标签的旋转和字体
+	if opts.LabelRotation != 0 || opts.FontFace != "" || opts.FontSize > 0 || opts.FontColor != "" {
+		sb.WriteString(`<c:txPr>`)
+		sb.WriteString(`<a:bodyPr rot="`)
+		sb.WriteString(itoa(int(opts.LabelRotation * 60000)))
+		sb.WriteString(`" vert="horz"/>`)
+		sb.WriteString(`<a:lstStyle/>`)
+		sb.WriteString(`<a:p>`)
+		sb.WriteString(`<a:pPr>`)
+		sb.WriteString(chartDefRPr(opts.FontFace, opts.FontSize, opts.FontColor, false))
+		sb.WriteString(`</a:pPr>`)
+		sb.WriteString(`<a:endParaRPr lang="`)
+		sb.WriteString(ax.lang)
+		sb.WriteString(`"/>`)
+		sb.WriteString(`</a:p>`)
+		sb.WriteString(`</c:txPr>`)
+	}
+
+	sb.WriteString(`<c:crossAx val="`)
+	sb.WriteString(itoa(ax.crossAx))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:crosses val="`)
+	sb.WriteString(ax.crosses)
+	sb.WriteString(`"/>`)
+
+	if ax.valueAxis {
+		sb.WriteString(`<c:crossBetween val="`)
+		sb.WriteString(ax.crossBetween)
+		sb.WriteString(`"/>`)
+		if opts.MajorUnit > 0 {
+			sb.WriteString(`<c:majorUnit val="`)
+			sb.WriteString(ftoa(opts.MajorUnit))
+			sb.WriteString(`"/>`)
+		}
+		if opts.MinorUnit > 0 {
+			sb.WriteString(`<c:minorUnit val="`)
+			sb.WriteString(ftoa(opts.MinorUnit))
+			sb.WriteString(`"/>`)
+		}
+	} else if ax.dateAxis {
+		// 按日期间隔排列，不自动退化为文本类别
+		sb.WriteString(`<c:auto val="0"/>`)
+		sb.WriteString(`<c:lblOffset val="100"/>`)
+		writeTimeUnit(&sb, "c:baseTimeUnit", opts.BaseUnit)
+		if opts.MajorUnit > 0 {
+			sb.WriteString(`<c:majorUnit val="`)
+			sb.WriteString(ftoa(opts.MajorUnit))
+			sb.WriteString(`"/>`)
+			writeTimeUnit(&sb, "c:majorTimeUnit", opts.MajorTimeUnit)
+		}
+		if opts.MinorUnit > 0 {
+			sb.WriteString(`<c:minorUnit val="`)
+			sb.WriteString(ftoa(opts.MinorUnit))
+			sb.WriteString(`"/>`)
+			writeTimeUnit(&sb, "c:minorTimeUnit", opts.MinorTimeUnit)
+		}
+	} else {
+		sb.WriteString(`<c:auto val="1"/>`)
+		sb.WriteString(`<c:lblAlgn val="ctr"/>`)
+		sb.WriteString(`<c:lblOffset val="100"/>`)
+	}
+
+	sb.WriteString(`</`)
+	sb.WriteString(tag)
+	sb.WriteString(`>`)
+	return sb.String()
+}
+
+// writeTimeUnit 写入日期轴的时间单位，为空时不写入
+func writeTimeUnit(sb *strings.Builder, tag string, unit TimeUnit) {
+	if unit == "" {
+		return
+	}
+	sb.WriteString(`<`)
+	sb.WriteString(tag)
+	sb.WriteString(` val="`)
+	sb.WriteString(string(unit))
+	sb.WriteString(`"/>`)
+}
+
+// chartDefRPr 生成图表文字的默认格式 a:defRPr，未设置的属性沿用图表默认值
+func chartDefRPr(fontFace string, fontSize float64, fontColor string, bold bool) string {
+	var sb strings.Builder
+	sb.WriteString(`<a:defRPr`)
+	if fontSize > 0 {
+		sb.WriteString(` sz="`)
+		sb.WriteString(itoa(int(fontSize * 100)))
+		sb.WriteString(`"`)
+	}
+	if bold {
+		sb.WriteString(` b="1"`)
+	} else {
+		sb.WriteString(` b="0"`)
+	}
+	if fontColor == "" && fontFace == "" {
+		sb.WriteString(`/>`)
+		return sb.String()
+	}
+	sb.WriteString(`>`)
+	if fontColor != "" {
+		sb.WriteString(solidFill(fontColor))
+	}
+	if fontFace != "" {
+		sb.WriteString(`<a:latin typeface="`)
+		sb.WriteString(escapeXML(fontTypeface(fontFace, "lt")))
+		sb.WriteString(`"/>`)
+		sb.WriteString(`<a:ea typeface="`)
+		sb.WriteString(escapeXML(fontTypeface(fontFace, "ea")))
+		sb.WriteString(`"/>`)
+	}
+	sb.WriteString(`</a:defRPr>`)
+	return sb.String()
+}
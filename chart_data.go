@@ -0,0 +1,284 @@
+package genppt
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChartData 有序的图表数据：类别和按顺序排列的命名系列
+// 系列顺序决定图例顺序和默认颜色，每次生成都保持一致
+type ChartData struct {
+	Categories []string      // 类别
+	Dates      []time.Time   // 日期类别，设置后使用日期轴（代替 Categories）
+	Series     []ChartValues // 系列，按添加顺序排列
+}
+
+// ChartValues ChartData 中的一个命名系列
+type ChartValues struct {
+	Name   string    // 系列名称
+	Values []float64 // 每个类别的值，缺失的值为 NaN
+}
+
+// NewChartData 创建有序图表数据
+func NewChartData(categories ...string) *ChartData {
+	return &ChartData{Categories: categories}
+}
+
+// AddSeries 按顺序追加一个系列
+func (d *ChartData) AddSeries(name string, values ...float64) *ChartData {
+	d.Series = append(d.Series, ChartValues{Name: name, Values: values})
+	return d
+}
+
+// ToSeries 转换为 AddChart 使用的图表系列
+func (d *ChartData) ToSeries() []ChartSeries {
+	series := make([]ChartSeries, 0, len(d.Series))
+	for _, s := range d.Series {
+		series = append(series, ChartSeries{
+			Name:   s.Name,
+			Labels: d.Categories,
+			Dates:  d.Dates,
+			Values: s.Values,
+		})
+	}
+	return series
+}
+
+// ChartDataFromRows 从表格数据创建图表数据
+// 第一行为表头，第一列为类别，其余每列为一个系列；空单元格为缺失值
+// 数值可以带千位分隔符，以 % 结尾的数值按百分比换算（如 "12.5%" 为 0.125）
+func ChartDataFromRows(rows [][]string) (*ChartData, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("图表数据为空")
+	}
+
+	data := &ChartData{}
+	header := rows[0]
+	for col := 1; col < len(header); col++ {
+		data.Series = append(data.Series, ChartValues{Name: strings.TrimSpace(header[col])})
+	}
+
+	for r, row := range rows[1:] {
+		if len(row) == 0 {
+			continue
+		}
+		data.Categories = append(data.Categories, strings.TrimSpace(row[0]))
+		for col := range data.Series {
+			val := math.NaN()
+			if col+1 < len(row) {
+				v, err := parseChartNumber(row[col+1])
+				if err != nil {
+					return nil, fmt.Errorf("第%d行第%d列: %w", r+2, col+2, err)
+				}
+				val = v
+			}
+			data.Series[col].Values = append(data.Series[col].Values, val)
+		}
+	}
+	return data, nil
+}
+
+// ChartDataFromCSV 从 CSV 创建图表数据，格式同 ChartDataFromRows
+func ChartDataFromCSV(r io.Reader) (*ChartData, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("读取CSV失败: %w", err)
+	}
+	// Excel 导出的 CSV 可能带有 UTF-8 BOM
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return ChartDataFromRows(rows)
+}
+
+// ChartDataFromStructs 从结构体切片创建图表数据，每个元素为一个类别
+// 字段通过 chart 标签配置：`chart:"销量"` 设置系列名称，`chart:"-"` 忽略字段，
+// `chart:",category"` 指定类别字段；未指定时第一个字符串或 time.Time 字段（或其指针）为类别，time.Time 类别使用日期轴，nil 指针为空类别
+// 数值字段（整数、浮点数及其指针）按字段顺序成为系列，nil 指针为缺失值
+func ChartDataFromStructs(slice any) (*ChartData, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("需要结构体切片，实际为 %T", slice)
+	}
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("需要结构体切片，实际为 %T", slice)
+	}
+
+	// 解析字段
+	categoryField := -1
+	var valueFields []int
+	data := &ChartData{}
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("chart")
+		if tag == "-" {
+			continue
+		}
+		name, flag, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch {
+		case flag == "category":
+			categoryField = i
+		case isNumberKind(fieldType.Kind()):
+			valueFields = append(valueFields, i)
+			data.Series = append(data.Series, ChartValues{Name: name})
+		case (fieldType.Kind() == reflect.String || fieldType == timeType) && categoryField < 0:
+			categoryField = i
+		}
+	}
+	if len(valueFields) == 0 {
+		return nil, fmt.Errorf("%s 没有数值字段", elemType.Name())
+	}
+	dateCategory := false
+	if categoryField >= 0 {
+		categoryType := elemType.Field(categoryField).Type
+		dateCategory = categoryType == timeType || (categoryType.Kind() == reflect.Pointer && categoryType.Elem() == timeType)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		if dateCategory {
+			var date time.Time
+			if field := elem.Field(categoryField); field.Kind() != reflect.Pointer {
+				date = field.Interface().(time.Time)
+			} else if !field.IsNil() {
+				date = field.Elem().Interface().(time.Time)
+			}
+			data.Dates = append(data.Dates, date)
+		} else {
+			category := ""
+			if categoryField >= 0 {
+				category = categoryValue(elem.Field(categoryField))
+			}
+			data.Categories = append(data.Categories, category)
+		}
+		for j, idx := range valueFields {
+			data.Series[j].Values = append(data.Series[j].Values, numberValue(elem.Field(idx)))
+		}
+	}
+	return data, nil
+}
+
+// categoryValue 返回类别字段的文本，指针取其指向的值，nil 指针为空字符串
+func categoryValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// timeType time.Time 的反射类型
+var timeType = reflect.TypeOf(time.Time{})
+
+// isNumberKind 是否为整数或浮点数类型
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// numberValue 返回数值字段的值，nil 指针返回 NaN
+func numberValue(v reflect.Value) float64 {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return math.NaN()
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	case v.CanFloat():
+		return v.Float()
+	}
+	return math.NaN()
+}
+
+// parseChartNumber 解析单元格中的数值，空单元格返回 NaN
+func parseChartNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return math.NaN(), nil
+	}
+	percent := strings.HasSuffix(s, "%")
+	s = strings.ReplaceAll(strings.TrimSuffix(s, "%"), ",", "")
+	val, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("无效的数值: %q", s)
+	}
+	if percent {
+		val /= 100
+	}
+	return val, nil
+}
+
+// AddChartData 使用有序图表数据添加图表，data 为 nil 时不添加
+func (s *Slide) AddChartData(chartType ChartType, data *ChartData, opts ChartOptions) *Slide {
+	if data == nil {
+		return s
+	}
+	return s.AddChart(chartType, data.ToSeries(), opts)
+}
+
+// AddBarChartData 添加柱状图（便捷方法），系列按 data 中的顺序排列
+func (s *Slide) AddBarChartData(title string, data *ChartData, opts ChartOptions) *Slide {
+	opts.Title = title
+	opts.ShowTitle = true
+	return s.AddChartData(ChartBar, data, opts)
+}
+
+// AddLineChartData 添加折线图（便捷方法），系列按 data 中的顺序排列
+func (s *Slide) AddLineChartData(title string, data *ChartData, opts ChartOptions) *Slide {
+	opts.Title = title
+	opts.ShowTitle = true
+	return s.AddChartData(ChartLine, data, opts)
+}
+
+// AddPieChartData 添加饼图（便捷方法），使用 data 中的第一个系列
+func (s *Slide) AddPieChartData(title string, data *ChartData, opts ChartOptions) *Slide {
+	if data == nil || len(data.Series) == 0 {
+		return s
+	}
+	series := data.ToSeries()[:1]
+	series[0].Name = title
+	opts.Title = title
+	opts.ShowTitle = true
+	return s.AddChart(ChartPie, series, opts)
+}
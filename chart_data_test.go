@@ -0,0 +1,193 @@
+package genppt
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestChartDataFromCSV 测试从 CSV 读取图表数据
+func TestChartDataFromCSV(t *testing.T) {
+	csvData := "\ufeff月份,收入,成本,增长率\n一月,\"1,200\",800,5%\n二月,1500,,12.5%\n三月,1800,1000\n"
+	data, err := ChartDataFromCSV(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("ChartDataFromCSV() 失败: %v", err)
+	}
+
+	if strings.Join(data.Categories, ",") != "一月,二月,三月" {
+		t.Errorf("类别错误: %v", data.Categories)
+	}
+	if len(data.Series) != 3 || data.Series[0].Name != "收入" || data.Series[2].Name != "增长率" {
+		t.Fatalf("系列顺序错误: %v", data.Series)
+	}
+	if data.Series[0].Values[0] != 1200 {
+		t.Error("应该去掉千位分隔符")
+	}
+	if data.Series[2].Values[1] != 0.125 {
+		t.Error("百分比应该换算为小数")
+	}
+	if !math.IsNaN(data.Series[1].Values[1]) || !math.IsNaN(data.Series[2].Values[2]) {
+		t.Error("空单元格和缺少的单元格应该为缺失值")
+	}
+
+	if _, err := ChartDataFromRows([][]string{{"月份", "收入"}, {"一月", "abc"}}); err == nil || !strings.Contains(err.Error(), "第2行第2列") {
+		t.Errorf("无效数值应该返回错误，实际为 %v", err)
+	}
+	if _, err := ChartDataFromRows(nil); err == nil {
+		t.Error("空数据应该返回错误")
+	}
+}
+
+// TestChartDataFromStructs 测试从结构体切片读取图表数据
+func TestChartDataFromStructs(t *testing.T) {
+	type sales struct {
+		Region  string
+		Revenue float64 `chart:"收入"`
+		Orders  int     `chart:"订单数"`
+		Note    string
+		Target  *float64 `chart:"目标"`
+		Ignored float64  `chart:"-"`
+		private float64
+	}
+	target := 100.0
+	rows := []*sales{
+		{Region: "华东", Revenue: 120.5, Orders: 10, Target: &target},
+		nil,
+		{Region: "华北", Revenue: 98, Orders: 7},
+	}
+	data, err := ChartDataFromStructs(rows)
+	if err != nil {
+		t.Fatalf("ChartDataFromStructs() 失败: %v", err)
+	}
+
+	if strings.Join(data.Categories, ",") != "华东,华北" {
+		t.Errorf("类别错误: %v", data.Categories)
+	}
+	var names []string
+	for _, s := range data.Series {
+		names = append(names, s.Name)
+	}
+	if strings.Join(names, ",") != "收入,订单数,目标" {
+		t.Errorf("系列应该按字段顺序排列: %v", names)
+	}
+	if data.Series[1].Values[1] != 7 || data.Series[2].Values[0] != 100 || !math.IsNaN(data.Series[2].Values[1]) {
+		t.Error("数值读取错误")
+	}
+
+	// 通过标签指定类别字段
+	type point struct {
+		Name string
+		Year int `chart:",category"`
+		Rate float64
+	}
+	data, err = ChartDataFromStructs([]point{{"A", 2023, 1.5}, {"B", 2024, 2}})
+	if err != nil {
+		t.Fatalf("ChartDataFromStructs() 失败: %v", err)
+	}
+	if strings.Join(data.Categories, ",") != "2023,2024" || len(data.Series) != 1 {
+		t.Error("应该使用标签指定的类别字段")
+	}
+
+	// time.Time 字段作为日期类别
+	type incident struct {
+		Day   time.Time
+		Count int `chart:"事件数"`
+	}
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	data, err = ChartDataFromStructs([]incident{{day, 3}, {day.AddDate(0, 0, 7), 1}})
+	if err != nil {
+		t.Fatalf("ChartDataFromStructs() 失败: %v", err)
+	}
+	if len(data.Dates) != 2 || len(data.Categories) != 0 || !data.ToSeries()[0].Dates[1].Equal(day.AddDate(0, 0, 7)) {
+		t.Error("time.Time 字段应该作为日期类别")
+	}
+
+	// 指针类别取其指向的值，nil 为空类别
+	type optional struct {
+		Name  *string
+		Value float64
+	}
+	east := "华东"
+	data, err = ChartDataFromStructs([]optional{{Name: &east, Value: 1}, {Value: 2}})
+	if err != nil {
+		t.Fatalf("ChartDataFromStructs() 失败: %v", err)
+	}
+	if len(data.Categories) != 2 || data.Categories[0] != "华东" || data.Categories[1] != "" {
+		t.Errorf("*string 类别应该取指向的值: %q", data.Categories)
+	}
+	type optionalDay struct {
+		Day   *time.Time
+		Value float64
+	}
+	data, err = ChartDataFromStructs([]optionalDay{{Day: &day, Value: 1}})
+	if err != nil {
+		t.Fatalf("ChartDataFromStructs() 失败: %v", err)
+	}
+	if len(data.Dates) != 1 || !data.Dates[0].Equal(day) {
+		t.Error("*time.Time 字段应该作为日期类别")
+	}
+
+	// nil 日期在图表和工作表中为空类别
+	data, err = ChartDataFromStructs([]optionalDay{{Day: &day, Value: 1}, {Value: 2}})
+	if err != nil {
+		t.Fatalf("ChartDataFromStructs() 失败: %v", err)
+	}
+	pres := New()
+	pres.AddSlide().AddChartData(ChartLine, data, DefaultChartOptions())
+	xmlStr := pres.slides[0].objects[0].(*chartObject).generateChartXML()
+	if !strings.Contains(xmlStr, `<c:ptCount val="2"/><c:pt idx="0"><c:v>45413</c:v></c:pt></c:numCache>`) {
+		t.Errorf("nil 日期应该为空类别: %s", xmlStr)
+	}
+	out, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	sheet := string(readZipParts(t, readZipParts(t, out)["ppt/embeddings/Microsoft_Excel_Worksheet1.xlsx"])["xl/worksheets/sheet1.xml"])
+	if strings.Contains(sheet, "-1067") || !strings.Contains(sheet, "<v>45413</v>") {
+		t.Errorf("nil 日期不应该写入工作表: %s", sheet)
+	}
+
+	if _, err := ChartDataFromStructs([]string{"a"}); err == nil {
+		t.Error("非结构体切片应该返回错误")
+	}
+}
+
+// TestChartDataOrder 测试有序图表数据保持系列顺序
+func TestChartDataOrder(t *testing.T) {
+	data := NewChartData("Q1", "Q2").
+		AddSeries("丙", 1, 2).
+		AddSeries("甲", 3, 4).
+		AddSeries("乙", 5, 6)
+
+	pres := New()
+	pres.AddSlide().
+		AddBarChartData("柱状图", data, DefaultChartOptions()).
+		AddLineChartData("折线图", data, DefaultChartOptions()).
+		AddPieChartData("饼图", data, DefaultChartOptions()).
+		AddChartData(ChartArea, nil, DefaultChartOptions())
+	objects := pres.slides[0].objects
+	if len(objects) != 3 {
+		t.Fatalf("应该有3个图表，实际为 %d", len(objects))
+	}
+
+	bar := objects[0].(*chartObject)
+	if bar.chartType != ChartBar || bar.options.Title != "柱状图" {
+		t.Error("柱状图类型或标题错误")
+	}
+	for i, name := range []string{"丙", "甲", "乙"} {
+		if bar.series[i].Name != name {
+			t.Errorf("第%d个系列应该是 %s，实际为 %s", i+1, name, bar.series[i].Name)
+		}
+	}
+	if pie := objects[2].(*chartObject); len(pie.series) != 1 || pie.series[0].Values[0] != 1 {
+		t.Error("饼图应该使用第一个系列")
+	}
+
+	// map 参数的便捷方法按系列名称排序
+	pres.AddSlide().AddBarChart("排序", []string{"A"}, map[string][]float64{"b": {1}, "a": {2}, "c": {3}}, DefaultChartOptions())
+	sorted := pres.slides[1].objects[0].(*chartObject)
+	if sorted.series[0].Name != "a" || sorted.series[2].Name != "c" {
+		t.Error("map 中的系列应该按名称排序")
+	}
+}
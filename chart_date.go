@@ -0,0 +1,68 @@
+package genppt
+
+import (
+	"math"
+	"time"
+)
+
+// excelEpoch Excel 1900 日期系统的零点，序列号1为1900年1月1日
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// dateSerial 返回日期在 Excel 1900 日期系统中的序列号，时间部分为小数
+// 使用日期自身的时区，不做时区转换；零值日期返回 NaN，作为空类别
+func dateSerial(t time.Time) float64 {
+	if t.IsZero() {
+		return math.NaN()
+	}
+	y, m, d := t.Date()
+	days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(excelEpoch).Hours() / 24
+	seconds := t.Hour()*3600 + t.Minute()*60 + t.Second()
+	return days + float64(seconds)/86400
+}
+
+// dateSerials 返回一组日期的序列号
+func dateSerials(dates []time.Time) []float64 {
+	values := make([]float64, len(dates))
+	for i, t := range dates {
+		values[i] = dateSerial(t)
+	}
+	return values
+}
+
+// hasDates 是否使用日期类别（任一系列设置了 Dates），散点图和气泡图除外
+func (c *chartObject) hasDates() bool {
+	if c.isXY() {
+		return false
+	}
+	for _, series := range c.series {
+		if len(series.Dates) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// categoryDates 返回日期类别：日期最多的系列的日期，数量相同时取靠前的
+func (c *chartObject) categoryDates() []time.Time {
+	var dates []time.Time
+	for _, series := range c.series {
+		if len(series.Dates) > len(dates) {
+			dates = series.Dates
+		}
+	}
+	return dates
+}
+
+// dateFormat 返回日期类别在工作表和图表缓存中的数字格式
+func (c *chartObject) dateFormat() string {
+	if c.options.CategoryAxis.NumberFormat != "" {
+		return c.options.CategoryAxis.NumberFormat
+	}
+	switch c.options.CategoryAxis.BaseUnit {
+	case TimeUnitMonths:
+		return "yyyy-mm"
+	case TimeUnitYears:
+		return "yyyy"
+	}
+	return "yyyy-mm-dd"
+}
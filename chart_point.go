@@ -0,0 +1,166 @@
+package genppt
+
+import (
+	"strings"
+)
+
+// DataPoint 单个数据点的格式，覆盖系列的颜色和数据标签
+type DataPoint struct {
+	Index        int         // 数据点序号（从0开始）
+	Color        string      // 填充颜色（折线图和散点图为标记点颜色）
+	Pattern      FillPattern // 图案填充，前景色为 Color（为空时使用系列颜色）
+	PatternColor string      // 图案的背景色，默认白色
+	Explode      int         // 饼图、环形图扇区分离的距离（半径的百分比）
+	Label        string      // 自定义数据标签文本，即使未开启 ShowValues 也会显示
+	NumberFormat string      // 数据标签的数字格式，如 "0.0%"，覆盖系列和图表的格式（显示数据标签时有效）
+}
+
+// point 返回系列中序号为 i 的数据点格式，未设置时返回 false
+// 同一序号设置多次时使用最后一个
+func (series ChartSeries) point(i int) (DataPoint, bool) {
+	for j := len(series.Points) - 1; j >= 0; j-- {
+		if series.Points[j].Index == i {
+			return series.Points[j], true
+		}
+	}
+	return DataPoint{}, false
+}
+
+// hasPointLabels 系列中是否有自定义标签的数据点，withFormat 为 true 时也包括设置了数字格式的数据点
+func (series ChartSeries) hasPointLabels(withFormat bool) bool {
+	for _, p := range series.Points {
+		if p.Label != "" || (withFormat && p.NumberFormat != "") {
+			return true
+		}
+	}
+	return false
+}
+
+// pointFill 生成数据点的填充，纯色或图案
+func pointFill(color string, pattern FillPattern, patternColor string) string {
+	if pattern == "" {
+		return solidFill(color)
+	}
+	var sb strings.Builder
+	sb.WriteString(`<a:pattFill prst="`)
+	sb.WriteString(string(pattern))
+	sb.WriteString(`">`)
+	sb.WriteString(`<a:fgClr>`)
+	sb.WriteString(colorElement(color, ""))
+	sb.WriteString(`</a:fgClr>`)
+	sb.WriteString(`<a:bgClr>`)
+	sb.WriteString(colorElement(defaultIfEmpty(patternColor, "FFFFFF"), ""))
+	sb.WriteString(`</a:bgClr>`)
+	sb.WriteString(`</a:pattFill>`)
+	return sb.String()
+}
+
+// generateDataPoints 生成系列的数据点格式 c:dPt，每个数据点一个
+// 饼图、环形图的每个扇区按顺序使用 Colors 中的颜色；其他图表只为设置了格式的数据点生成
+// kind 为 bar、line、area、pie、bubble，折线图（含散点图）只改变标记点
+func (c *chartObject) generateDataPoints(series ChartSeries, seriesColor, kind string) string {
+	var sb strings.Builder
+	for i := range series.Values {
+		p, ok := series.point(i)
+		color := seriesColor
+		if kind == "pie" {
+			color = ""
+			if i < len(c.options.Colors) {
+				color = c.options.Colors[i]
+			}
+		}
+		if ok && p.Color != "" {
+			color = p.Color
+		}
+		hasFill := color != "" && (kind == "pie" || p.Color != "" || p.Pattern != "")
+		explode := kind == "pie" && p.Explode > 0
+		if !hasFill && !explode {
+			continue
+		}
+
+		sb.WriteString(`<c:dPt>`)
+		sb.WriteString(`<c:idx val="`)
+		sb.WriteString(itoa(i))
+		sb.WriteString(`"/>`)
+		switch kind {
+		case "bar":
+			sb.WriteString(`<c:invertIfNegative val="0"/>`)
+		case "line":
+			sb.WriteString(`<c:marker>`)
+			sb.WriteString(`<c:symbol val="circle"/>`)
+			sb.WriteString(`<c:size val="7"/>`)
+			sb.WriteString(`<c:spPr>`)
+			sb.WriteString(pointFill(color, p.Pattern, p.PatternColor))
+			sb.WriteString(`</c:spPr>`)
+			sb.WriteString(`</c:marker>`)
+		case "bubble":
+			sb.WriteString(`<c:bubble3D val="0"/>`)
+		}
+		if explode {
+			sb.WriteString(`<c:explosion val="`)
+			sb.WriteString(itoa(p.Explode))
+			sb.WriteString(`"/>`)
+		}
+		if kind != "line" && hasFill {
+			sb.WriteString(`<c:spPr>`)
+			sb.WriteString(pointFill(color, p.Pattern, p.PatternColor))
+			sb.WriteString(`</c:spPr>`)
+		}
+		sb.WriteString(`</c:dPt>`)
+	}
+	return sb.String()
+}
+
+// generatePointLabels 生成数据点标签 c:dLbl，lang 为文字的语言
+// flags 为只设置了数字格式的数据点的显示内容，为空时不生成这些数据点的标签
+func (series ChartSeries) generatePointLabels(lang, flags string) string {
+	var sb strings.Builder
+	for i := range series.Values {
+		p, ok := series.point(i)
+		if !ok || (p.Label == "" && (p.NumberFormat == "" || flags == "")) {
+			continue
+		}
+		sb.WriteString(`<c:dLbl>`)
+		sb.WriteString(`<c:idx val="`)
+		sb.WriteString(itoa(i))
+		sb.WriteString(`"/>`)
+		if p.Label == "" {
+			sb.WriteString(labelNumFmt(p.NumberFormat))
+			sb.WriteString(flags)
+			sb.WriteString(`</c:dLbl>`)
+			continue
+		}
+		sb.WriteString(`<c:tx>`)
+		sb.WriteString(`<c:rich>`)
+		sb.WriteString(`<a:bodyPr/>`)
+		sb.WriteString(`<a:lstStyle/>`)
+		sb.WriteString(`<a:p>`)
+		sb.WriteString(`<a:r>`)
+		sb.WriteString(`<a:rPr lang="`)
+		sb.WriteString(lang)
+		sb.WriteString(`"/>`)
+		sb.WriteString(`<a:t>`)
+		sb.WriteString(escapeXML(p.Label))
+		sb.WriteString(`</a:t>`)
+		sb.WriteString(`</a:r>`)
+		sb.WriteString(`</a:p>`)
+		sb.WriteString(`</c:rich>`)
+		sb.WriteString(`</c:tx>`)
+		sb.WriteString(labelNumFmt(p.NumberFormat))
+		sb.WriteString(valueLabelFlags())
+		sb.WriteString(`</c:dLbl>`)
+	}
+	return sb.String()
+}
+
+// valueLabelFlags 生成只显示数值的数据标签显示内容
+func valueLabelFlags() string {
+	var sb strings.Builder
+	sb.WriteString(`<c:showLegendKey val="0"/>`)
+	sb.WriteString(`<c:showVal val="1"/>`)
+	sb.WriteString(`<c:showCatName val="0"/>`)
+	sb.WriteString(`<c:showSerName val="0"/>`)
+	sb.WriteString(`<c:showPercent val="0"/>`)
+	sb.WriteString(`<c:showBubbleSize val="0"/>`)
+	return sb.String()
+}
@@ -0,0 +1,185 @@
+package genppt
+
+import (
+	"strings"
+)
+
+// ChartStyle 图表整体样式：文字、图表区、绘图区、标题和图例
+// 颜色可以设置为 "none" 表示无填充或无边框，为空时使用 PowerPoint 默认样式
+type ChartStyle struct {
+	FontFace  string  // 所有文字的字体，"+mj"/"+mn" 表示主题的标题/正文字体
+	FontSize  float64 // 所有文字的字号（磅）
+	FontColor string  // 所有文字的颜色
+	Language  string  // 文字的语言，默认 "zh-CN"
+
+	Background     string  // 图表区填充色
+	BorderColor    string  // 图表区边框颜色
+	BorderWidth    float64 // 图表区边框宽度（磅），默认0.75
+	RoundedCorners bool    // 图表区圆角
+
+	PlotBackground  string  // 绘图区填充色
+	PlotBorderColor string  // 绘图区边框颜色
+	PlotBorderWidth float64 // 绘图区边框宽度（磅），默认0.75
+
+	TitleFontFace  string  // 标题字体，为空时使用 FontFace
+	TitleFontSize  float64 // 标题字号（磅），默认14
+	TitleFontColor string  // 标题颜色，为空时使用 FontColor
+	TitleBold      *bool   // 标题加粗，为空时加粗，Bool(false) 为常规字体
+
+	LegendFontSize  float64 // 图例字号（磅），为空时使用 FontSize
+	LegendFontColor string  // 图例颜色，为空时使用 FontColor
+	LegendOverlay   bool    // 图例覆盖在绘图区上，不占用绘图区空间
+}
+
+// DefaultChartStyle 返回默认图表样式：14磅粗体标题，其余沿用 PowerPoint 默认样式
+func DefaultChartStyle() ChartStyle {
+	return ChartStyle{
+		Language:      "zh-CN",
+		TitleFontSize: 14,
+	}
+}
+
+// MinimalChartStyle 返回简洁图表样式：无背景和边框，灰色小字
+func MinimalChartStyle() ChartStyle {
+	return ChartStyle{
+		FontSize:       9,
+		FontColor:      "595959",
+		Background:     "none",
+		BorderColor:    "none",
+		TitleFontSize:  12,
+		TitleFontColor: "404040",
+	}
+}
+
+// DarkChartStyle 返回深色图表样式，适合深色背景的幻灯片
+func DarkChartStyle() ChartStyle {
+	return ChartStyle{
+		FontColor:      "D9D9D9",
+		Background:     "262626",
+		BorderColor:    "none",
+		PlotBackground: "none",
+		TitleFontSize:  14,
+		TitleFontColor: "FFFFFF",
+	}
+}
+
+// CardChartStyle 返回卡片图表样式：白色背景、浅灰色边框和圆角
+func CardChartStyle() ChartStyle {
+	return ChartStyle{
+		FontColor:      "404040",
+		Background:     "FFFFFF",
+		BorderColor:    "D9D9D9",
+		BorderWidth:    0.75,
+		RoundedCorners: true,
+		TitleFontSize:  16,
+	}
+}
+
+// style 返回图表使用的样式，补全默认值
+func (c *chartObject) style() ChartStyle {
+	st := DefaultChartStyle()
+	if c.options.Style != nil {
+		st = *c.options.Style
+	}
+	st.Language = defaultIfEmpty(st.Language, "zh-CN")
+	st.TitleFontSize = defaultIfZero(st.TitleFontSize, 14)
+	st.TitleFontFace = defaultIfEmpty(st.TitleFontFace, st.FontFace)
+	st.TitleFontColor = defaultIfEmpty(st.TitleFontColor, st.FontColor)
+	if st.TitleBold == nil {
+		st.TitleBold = Bool(true)
+	}
+	return st
+}
+
+// chartShapeProps 生成图表区、绘图区等元素的 c:spPr，未设置填充和边框时返回空字符串
+func chartShapeProps(fill, borderColor string, borderWidth float64) string {
+	if fill == "" && borderColor == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<c:spPr>`)
+	if fill == "none" {
+		sb.WriteString(`<a:noFill/>`)
+	} else if fill != "" {
+		sb.WriteString(solidFill(fill))
+	}
+	if borderColor == "none" {
+		sb.WriteString(`<a:ln><a:noFill/></a:ln>`)
+	} else if borderColor != "" {
+		sb.WriteString(`<a:ln w="`)
+		sb.WriteString(itoa(int(defaultIfZero(borderWidth, 0.75) * EMUPerPoint)))
+		sb.WriteString(`">`)
+		sb.WriteString(solidFill(borderColor))
+		sb.WriteString(`</a:ln>`)
+	}
+	sb.WriteString(`</c:spPr>`)
+	return sb.String()
+}
+
+// chartTextProps 生成图表文字的默认格式 c:txPr，未设置字体、字号和颜色时返回空字符串
+func chartTextProps(fontFace string, fontSize float64, fontColor, lang string) string {
+	if fontFace == "" && fontSize <= 0 && fontColor == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<c:txPr>`)
+	sb.WriteString(`<a:bodyPr/>`)
+	sb.WriteString(`<a:lstStyle/>`)
+	sb.WriteString(`<a:p>`)
+	sb.WriteString(`<a:pPr>`)
+	sb.WriteString(chartDefRPr(fontFace, fontSize, fontColor, false))
+	sb.WriteString(`</a:pPr>`)
+	sb.WriteString(`<a:endParaRPr lang="`)
+	sb.WriteString(lang)
+	sb.WriteString(`"/>`)
+	sb.WriteString(`</a:p>`)
+	sb.WriteString(`</c:txPr>`)
+	return sb.String()
+}
+
+// generateTitle 生成图表标题 c:title
+func (c *chartObject) generateTitle(st ChartStyle) string {
+	var sb strings.Builder
+	sb.WriteString(`<c:title>`)
+	sb.WriteString(`<c:tx>`)
+	sb.WriteString(`<c:rich>`)
+	sb.WriteString(`<a:bodyPr/>`)
+	sb.WriteString(`<a:lstStyle/>`)
+	sb.WriteString(`<a:p>`)
+	sb.WriteString(`<a:pPr>`)
+	sb.WriteString(chartDefRPr(st.TitleFontFace, st.TitleFontSize, st.TitleFontColor, *st.TitleBold))
+	sb.WriteString(`</a:pPr>`)
+	sb.WriteString(`<a:r>`)
+	sb.WriteString(`<a:rPr lang="`)
+	sb.WriteString(st.Language)
+	sb.WriteString(`" sz="`)
+	sb.WriteString(itoa(int(st.TitleFontSize * 100)))
+	sb.WriteString(`" b="`)
+	sb.WriteString(boolVal(*st.TitleBold))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<a:t>`)
+	sb.WriteString(escapeXML(c.options.Title))
+	sb.WriteString(`</a:t>`)
+	sb.WriteString(`</a:r>`)
+	sb.WriteString(`</a:p>`)
+	sb.WriteString(`</c:rich>`)
+	sb.WriteString(`</c:tx>`)
+	sb.WriteString(`<c:overlay val="0"/>`)
+	sb.WriteString(`</c:title>`)
+	return sb.String()
+}
+
+// generateLegend 生成图例 c:legend
+func (c *chartObject) generateLegend(st ChartStyle) string {
+	var sb strings.Builder
+	sb.WriteString(`<c:legend>`)
+	sb.WriteString(`<c:legendPos val="`)
+	sb.WriteString(c.options.LegendPos)
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<c:overlay val="`)
+	sb.WriteString(boolVal(st.LegendOverlay))
+	sb.WriteString(`"/>`)
+	sb.WriteString(chartTextProps("", st.LegendFontSize, st.LegendFontColor, st.Language))
+	sb.WriteString(`</c:legend>`)
+	return sb.String()
+}
@@ -3,8 +3,10 @@ package genppt
 import (
 	"archive/zip"
 	"bytes"
+	"math"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestAddBarChart 测试柱状图
@@ -148,6 +150,14 @@ func TestChartTypes(t *testing.T) {
 		ChartPie,
 		ChartDoughnut,
 		ChartArea,
+		ChartScatter,
+		ChartBubble,
+		ChartBarHorizontal,
+		ChartBarPercentStacked,
+		ChartLinePercentStacked,
+		ChartAreaStacked,
+		ChartRadar,
+		ChartRadarFilled,
 	}
 
 	for _, ct := range chartTypes {
@@ -164,3 +174,880 @@ func TestChartTypes(t *testing.T) {
 		}
 	}
 }
+
+// TestChartWorkbook 测试图表嵌入的工作簿
+func TestChartWorkbook(t *testing.T) {
+	pres := New()
+	pres.AddSlide().AddChart(ChartBar, []ChartSeries{
+		{Name: "收入", Labels: []string{"一月", "二月", "三月"}, Values: []float64{10, 20, 30}},
+		{Name: "成本", Labels: []string{"一月", "二月", "三月"}, Values: []float64{5, 8, 12}},
+	}, DefaultChartOptions())
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+
+	chartXML := string(parts["ppt/charts/chart1.xml"])
+	for _, s := range []string{
+		`<c:f>Sheet1!$C$1</c:f>`,
+		`<c:f>Sheet1!$A$2:$A$4</c:f>`,
+		`<c:f>Sheet1!$B$2:$B$4</c:f>`,
+		`<c:externalData r:id="rId1"><c:autoUpdate val="0"/></c:externalData>`,
+	} {
+		if !strings.Contains(chartXML, s) {
+			t.Errorf("图表应该包含 %s", s)
+		}
+	}
+	if !strings.Contains(string(parts["ppt/charts/_rels/chart1.xml.rels"]), `Target="../embeddings/Microsoft_Excel_Worksheet1.xlsx"`) {
+		t.Error("图表关系应该指向嵌入的工作簿")
+	}
+	if !strings.Contains(string(parts["[Content_Types].xml"]), `<Default Extension="xlsx"`) {
+		t.Error("缺少 xlsx 内容类型")
+	}
+
+	workbook := readZipParts(t, parts["ppt/embeddings/Microsoft_Excel_Worksheet1.xlsx"])
+	checkWellFormed(t, workbook)
+	sheet := string(workbook["xl/worksheets/sheet1.xml"])
+	for _, s := range []string{
+		`<c r="B1" t="inlineStr"><is><t>收入</t></is></c>`,
+		`<c r="A3" t="inlineStr"><is><t>二月</t></is></c>`,
+		`<c r="C4"><v>12</v></c>`,
+	} {
+		if !strings.Contains(sheet, s) {
+			t.Errorf("工作表应该包含 %s", s)
+		}
+	}
+
+	// 系列的类别数量不同时，A列使用最长的类别
+	uneven := &chartObject{chartType: ChartLine, series: []ChartSeries{
+		{Name: "实际", Labels: []string{"一月", "二月"}, Values: []float64{1, 2}},
+		{Name: "预测", Labels: []string{"一月", "二月", "三月", "四月"}, Values: []float64{1, 2, 3, 4}},
+		{Name: "去年", Labels: []string{"一月", "二月", "三月"}, Values: []float64{2, 2, 2}},
+	}, options: DefaultChartOptions()}
+	rows := uneven.worksheetRows()
+	if len(rows) != 5 || rows[3][0].value != "三月" || rows[4][0].value != "四月" {
+		t.Errorf("A列应该使用最长的类别: %+v", rows)
+	}
+
+	// 打开后新增的图表不覆盖原有的工作簿
+	opened, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	opened.AddSlide().AddPieChart("饼图", []string{"A"}, []float64{1}, DefaultChartOptions())
+	data, err = opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts = readZipParts(t, data)
+	if parts["ppt/embeddings/Microsoft_Excel_Worksheet1.xlsx"] == nil || parts["ppt/embeddings/Microsoft_Excel_Worksheet2.xlsx"] == nil {
+		t.Error("打开文件后应该保留原有工作簿并新增工作簿")
+	}
+}
+
+// TestScatterChart 测试散点图
+func TestScatterChart(t *testing.T) {
+	tests := []struct {
+		chartType ChartType
+		contains  []string
+	}{
+		{ChartScatter, []string{`<a:ln w="28575"><a:noFill/></a:ln>`, `<c:smooth val="0"/>`}},
+		{ChartScatterLine, []string{`<a:ln w="28575"><a:solidFill>`, `<c:smooth val="0"/>`}},
+		{ChartScatterSmooth, []string{`<c:smooth val="1"/>`}},
+	}
+
+	for _, tt := range tests {
+		pres := New()
+		pres.AddSlide().AddChart(tt.chartType, []ChartSeries{
+			{Name: "身高", XValues: []float64{1.5, 2, 3}, Values: []float64{10, 20, 15}},
+			{Name: "体重", Values: []float64{5, 6}},
+		}, DefaultChartOptions())
+		chart := pres.slides[0].objects[0].(*chartObject)
+		xmlStr := chart.generateChartXML()
+
+		for _, s := range append(tt.contains,
+			`<c:scatterChart><c:scatterStyle val="lineMarker"/>`,
+			`<c:xVal><c:numRef><c:f>Sheet1!$A$2:$A$4</c:f>`,
+			`<c:yVal><c:numRef><c:f>Sheet1!$B$2:$B$4</c:f>`,
+			// 未设置X值时使用序号
+			`<c:xVal><c:numRef><c:f>Sheet1!$C$2:$C$3</c:f><c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>1</c:v></c:pt><c:pt idx="1"><c:v>2</c:v></c:pt>`,
+			`<c:crossBetween val="midCat"/>`,
+		) {
+			if !strings.Contains(xmlStr, s) {
+				t.Errorf("%s 应该包含 %s", tt.chartType, s)
+			}
+		}
+		if strings.Contains(xmlStr, "<c:catAx>") || strings.Count(xmlStr, "<c:valAx>") != 2 {
+			t.Errorf("%s 应该有两个数值轴", tt.chartType)
+		}
+	}
+}
+
+// TestBubbleChart 测试气泡图
+func TestBubbleChart(t *testing.T) {
+	pres := New()
+	pres.AddSlide().AddChart(ChartBubble, []ChartSeries{
+		{Name: "产品", XValues: []float64{1, 2}, Values: []float64{3, 4}, Sizes: []float64{10, 30}},
+		{Name: "竞品", XValues: []float64{5}, Values: []float64{6}},
+	}, DefaultChartOptions())
+	chart := pres.slides[0].objects[0].(*chartObject)
+	xmlStr := chart.generateChartXML()
+
+	for _, s := range []string{
+		`<c:bubbleChart><c:varyColors val="0"/>`,
+		`<c:bubbleSize><c:numRef><c:f>Sheet1!$C$2:$C$3</c:f>`,
+		`<c:pt idx="1"><c:v>30</c:v></c:pt>`,
+		`<c:xVal><c:numRef><c:f>Sheet1!$D$2</c:f>`,
+		`<c:bubble3D val="0"/>`,
+		`<c:bubbleScale val="100"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("气泡图应该包含 %s", s)
+		}
+	}
+	if strings.Count(xmlStr, "<c:valAx>") != 2 {
+		t.Error("气泡图应该有两个数值轴")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+	sheet := string(readZipParts(t, parts["ppt/embeddings/Microsoft_Excel_Worksheet1.xlsx"])["xl/worksheets/sheet1.xml"])
+	for _, s := range []string{
+		`<c r="B1" t="inlineStr"><is><t>产品</t></is></c>`,
+		`<c r="C3"><v>30</v></c>`,
+		`<c r="F2"><v>1</v></c>`,
+	} {
+		if !strings.Contains(sheet, s) {
+			t.Errorf("工作表应该包含 %s", s)
+		}
+	}
+}
+
+// TestComboChart 测试组合图和次坐标轴
+func TestComboChart(t *testing.T) {
+	pres := New()
+	pres.AddSlide().AddChart(ChartBar, []ChartSeries{
+		{Name: "收入", Labels: []string{"一月", "二月"}, Values: []float64{100, 120}},
+		{Name: "利润率", Labels: []string{"一月", "二月"}, Values: []float64{12, 15}, Type: ChartLine, SecondaryAxis: true},
+		{Name: "成本", Labels: []string{"一月", "二月"}, Values: []float64{80, 90}},
+	}, DefaultChartOptions())
+	chart := pres.slides[0].objects[0].(*chartObject)
+	xmlStr := chart.generateChartXML()
+
+	barStart := strings.Index(xmlStr, "<c:barChart>")
+	barEnd := strings.Index(xmlStr, "</c:barChart>")
+	lineStart := strings.Index(xmlStr, "<c:lineChart>")
+	if barStart < 0 || lineStart < barEnd {
+		t.Fatal("应该先生成主坐标轴的柱状图，再生成次坐标轴的折线图")
+	}
+	bar := xmlStr[barStart:barEnd]
+	line := xmlStr[lineStart:strings.Index(xmlStr, "</c:lineChart>")]
+
+	if strings.Count(bar, "<c:ser>") != 2 || !strings.Contains(bar, `<c:axId val="1"/><c:axId val="2"/>`) {
+		t.Error("柱状图应该包含两个系列并使用主坐标轴")
+	}
+	// 系列序号和工作表列在组合图中保持不变
+	if !strings.Contains(line, `<c:idx val="1"/><c:order val="1"/>`) || !strings.Contains(line, `<c:f>Sheet1!$C$2:$C$3</c:f>`) {
+		t.Error("折线图系列的序号或引用错误")
+	}
+	if !strings.Contains(line, `<c:axId val="3"/><c:axId val="4"/>`) {
+		t.Error("折线图应该使用次坐标轴")
+	}
+	for _, s := range []string{
+		`<c:catAx><c:axId val="3"/><c:scaling><c:orientation val="minMax"/></c:scaling><c:delete val="1"/>`,
+		`<c:axPos val="r"/>`,
+		`<c:crossAx val="3"/><c:crosses val="max"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("缺少次坐标轴 %s", s)
+		}
+	}
+
+	// 没有次坐标轴时只有两个坐标轴
+	single := &chartObject{chartType: ChartLine, series: []ChartSeries{{Name: "A", Values: []float64{1}, Type: ChartBar}}, options: DefaultChartOptions()}
+	xmlStr = single.generateChartXML()
+	if strings.Contains(xmlStr, `<c:axId val="3"/>`) || !strings.Contains(xmlStr, "<c:barChart>") {
+		t.Error("系列类型应该覆盖图表类型，且不生成次坐标轴")
+	}
+}
+
+// TestChartAxisOptions 测试坐标轴选项
+func TestChartAxisOptions(t *testing.T) {
+	opts := DefaultChartOptions()
+	opts.ValueAxis = AxisOptions{
+		Title:        "完成率",
+		Min:          0.9,
+		Max:          1,
+		MajorUnit:    0.02,
+		NumberFormat: "0%",
+		Gridlines:    GridlinesBoth,
+		FontSize:     9,
+		FontColor:    "666666",
+	}
+	opts.CategoryAxis = AxisOptions{
+		Title:         "月份",
+		Reverse:       true,
+		LabelRotation: -45,
+		Min:           5, // 类别轴忽略
+	}
+
+	pres := New()
+	pres.AddSlide().AddChart(ChartLine, []ChartSeries{
+		{Name: "完成率", Labels: []string{"一月", "二月"}, Values: []float64{0.95, 0.98}},
+	}, opts)
+	xmlStr := pres.slides[0].objects[0].(*chartObject).generateChartXML()
+
+	catAx := xmlStr[strings.Index(xmlStr, "<c:catAx>"):strings.Index(xmlStr, "</c:catAx>")]
+	valAx := xmlStr[strings.Index(xmlStr, "<c:valAx>"):strings.Index(xmlStr, "</c:valAx>")]
+
+	for _, s := range []string{
+		`<c:scaling><c:orientation val="minMax"/><c:max val="1"/><c:min val="0.9"/></c:scaling>`,
+		`<c:majorGridlines/><c:minorGridlines/>`,
+		`<a:bodyPr rot="-5400000" vert="horz"/>`,
+		`<a:t>完成率</a:t>`,
+		`<c:numFmt formatCode="0%" sourceLinked="0"/>`,
+		`<a:defRPr sz="900" b="0"><a:solidFill><a:srgbClr val="666666"/></a:solidFill></a:defRPr>`,
+		`<c:majorUnit val="0.02"/>`,
+	} {
+		if !strings.Contains(valAx, s) {
+			t.Errorf("数值轴应该包含 %s", s)
+		}
+	}
+	for _, s := range []string{
+		`<c:scaling><c:orientation val="maxMin"/></c:scaling>`,
+		`<a:t>月份</a:t>`,
+		`<c:txPr><a:bodyPr rot="-2700000" vert="horz"/>`,
+	} {
+		if !strings.Contains(catAx, s) {
+			t.Errorf("类别轴应该包含 %s", s)
+		}
+	}
+	if strings.Contains(catAx, "<c:majorGridlines/>") {
+		t.Error("类别轴默认不显示网格线")
+	}
+
+	// 对数刻度和隐藏坐标轴
+	opts = DefaultChartOptions()
+	opts.ValueAxis = AxisOptions{LogBase: 10, Gridlines: GridlinesNone}
+	opts.CategoryAxis = AxisOptions{Hidden: true}
+	chart := &chartObject{chartType: ChartBar, series: []ChartSeries{{Values: []float64{1, 1000}}}, options: opts}
+	xmlStr = chart.generateChartXML()
+	if !strings.Contains(xmlStr, `<c:scaling><c:logBase val="10"/><c:orientation val="minMax"/></c:scaling>`) {
+		t.Error("缺少对数刻度")
+	}
+	if strings.Contains(xmlStr, "<c:majorGridlines/>") {
+		t.Error("不应该显示网格线")
+	}
+	if !strings.Contains(xmlStr, `<c:catAx><c:axId val="1"/><c:scaling><c:orientation val="minMax"/></c:scaling><c:delete val="1"/>`) {
+		t.Error("类别轴应该隐藏")
+	}
+}
+
+// TestChartNumberPrecision 测试图表数值的精度和数字格式
+func TestChartNumberPrecision(t *testing.T) {
+	for _, tt := range []struct {
+		in   float64
+		want string
+	}{
+		{0.125, "0.125"},
+		{-0.5, "-0.5"},
+		{-1.25, "-1.25"},
+		{1234567.891, "1234567.891"},
+		{0.0001, "0.0001"},
+		{42, "42"},
+	} {
+		if got := ftoa(tt.in); got != tt.want {
+			t.Errorf("ftoa(%v) = %s，期望 %s", tt.in, got, tt.want)
+		}
+	}
+
+	opts := DefaultChartOptions()
+	opts.ShowValues = true
+	opts.LabelFormat = "#,##0"
+	pres := New()
+	pres.AddSlide().AddChart(ChartBar, []ChartSeries{
+		{Name: "收入", Labels: []string{"A", "B", "C"}, Values: []float64{1234.5, -0.25, math.NaN()}},
+		{Name: "增长率", Labels: []string{"A", "B", "C"}, Values: []float64{0.125, 0.3, 0.05}, NumberFormat: "0.0%"},
+	}, opts)
+	xmlStr := pres.slides[0].objects[0].(*chartObject).generateChartXML()
+
+	for _, s := range []string{
+		`<c:pt idx="1"><c:v>-0.25</c:v></c:pt>`,
+		`<c:formatCode>0.0%</c:formatCode>`,
+		`<c:dLbls><c:numFmt formatCode="0.0%" sourceLinked="0"/>`,
+		`<c:dLbls><c:numFmt formatCode="#,##0" sourceLinked="0"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("应该包含 %s", s)
+		}
+	}
+	// 所有图表组使用相同的数据标签
+	for _, chartType := range []ChartType{ChartLine, ChartArea, ChartRadar, ChartScatter, ChartBubble} {
+		c := &chartObject{chartType: chartType, series: pres.slides[0].objects[0].(*chartObject).series, options: opts}
+		if !strings.Contains(c.generateChartXML(), `<c:dLbls><c:numFmt formatCode="#,##0" sourceLinked="0"/><c:showLegendKey val="0"/><c:showVal val="1"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`) {
+			t.Errorf("%s 应该包含图表组的数据标签", chartType)
+		}
+	}
+
+	// NaN 作为空白点
+	if strings.Contains(xmlStr, "NaN") || !strings.Contains(xmlStr, `<c:ptCount val="3"/><c:pt idx="0"><c:v>1234.5</c:v></c:pt><c:pt idx="1"><c:v>-0.25</c:v></c:pt></c:numCache>`) {
+		t.Error("NaN 应该作为空白点")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	workbook := readZipParts(t, readZipParts(t, data)["ppt/embeddings/Microsoft_Excel_Worksheet1.xlsx"])
+	checkWellFormed(t, workbook)
+	if !strings.Contains(string(workbook["xl/styles.xml"]), `<numFmt numFmtId="164" formatCode="0.0%"/>`) {
+		t.Error("工作簿应该包含数字格式")
+	}
+	if !strings.Contains(string(workbook["xl/worksheets/sheet1.xml"]), `<c r="C2" s="1"><v>0.125</v></c>`) {
+		t.Error("工作表单元格应该使用数字格式")
+	}
+}
+
+// TestPiePercentLabels 测试饼图百分比标签
+func TestPiePercentLabels(t *testing.T) {
+	opts := DefaultChartOptions()
+	opts.PercentOnly = true
+	opts.LabelFormat = "0.0%"
+	for _, ct := range []ChartType{ChartPie, ChartDoughnut} {
+		chart := &chartObject{chartType: ct, series: []ChartSeries{{Labels: []string{"A", "B"}, Values: []float64{1, 3}}}, options: opts}
+		xmlStr := chart.generateChartXML()
+		if !strings.Contains(xmlStr, `<c:dLbls><c:numFmt formatCode="0.0%" sourceLinked="0"/><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="1"/>`) {
+			t.Errorf("%s 应该只显示百分比标签", ct)
+		}
+	}
+}
+
+// TestHorizontalBarChart 测试条形图
+func TestHorizontalBarChart(t *testing.T) {
+	chart := &chartObject{chartType: ChartBarHorizontalStacked, series: []ChartSeries{
+		{Name: "A", Labels: []string{"x", "y"}, Values: []float64{1, 2}},
+		{Name: "B", Labels: []string{"x", "y"}, Values: []float64{3, 4}},
+	}, options: DefaultChartOptions()}
+	xmlStr := chart.generateChartXML()
+
+	for _, s := range []string{
+		`<c:barDir val="bar"/><c:grouping val="stacked"/>`,
+		`<c:overlap val="100"/>`,
+		`<c:catAx><c:axId val="1"/><c:scaling><c:orientation val="minMax"/></c:scaling><c:delete val="0"/><c:axPos val="l"/>`,
+		`<c:valAx><c:axId val="2"/><c:scaling><c:orientation val="minMax"/></c:scaling><c:delete val="0"/><c:axPos val="b"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("应该包含 %s", s)
+		}
+	}
+
+	// 条形图不参与组合，系列类型被忽略
+	chart.series[1].Type = ChartLine
+	if strings.Contains(chart.generateChartXML(), "<c:lineChart>") {
+		t.Error("条形图不应该生成组合图")
+	}
+}
+
+// TestPercentStackedCharts 测试百分比堆叠图
+func TestPercentStackedCharts(t *testing.T) {
+	tests := []struct {
+		chartType ChartType
+		contains  string
+	}{
+		{ChartBarPercentStacked, `<c:barDir val="col"/><c:grouping val="percentStacked"/>`},
+		{ChartBarHorizontalPercentStacked, `<c:barDir val="bar"/><c:grouping val="percentStacked"/>`},
+		{ChartLinePercentStacked, `<c:lineChart><c:grouping val="percentStacked"/>`},
+		{ChartLineStacked, `<c:lineChart><c:grouping val="stacked"/>`},
+		{ChartAreaPercentStacked, `<c:areaChart><c:grouping val="percentStacked"/>`},
+		{ChartAreaStacked, `<c:areaChart><c:grouping val="stacked"/>`},
+		{ChartBar, `<c:grouping val="clustered"/>`},
+	}
+	for _, tt := range tests {
+		chart := &chartObject{chartType: tt.chartType, series: []ChartSeries{{Name: "A", Labels: []string{"x"}, Values: []float64{1}}}, options: DefaultChartOptions()}
+		if !strings.Contains(chart.generateChartXML(), tt.contains) {
+			t.Errorf("%s 应该包含 %s", tt.chartType, tt.contains)
+		}
+	}
+}
+
+// TestRadarChart 测试雷达图
+func TestRadarChart(t *testing.T) {
+	series := []ChartSeries{{Name: "A", Labels: []string{"速度", "力量", "耐力"}, Values: []float64{3, 4, 5}}}
+
+	chart := &chartObject{chartType: ChartRadar, series: series, options: DefaultChartOptions()}
+	xmlStr := chart.generateChartXML()
+	for _, s := range []string{
+		`<c:radarChart><c:radarStyle val="marker"/><c:varyColors val="0"/>`,
+		`<c:symbol val="circle"/>`,
+		`<c:axId val="1"/><c:axId val="2"/></c:radarChart>`,
+		`<c:axPos val="b"/><c:majorGridlines/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("雷达图应该包含 %s", s)
+		}
+	}
+
+	chart = &chartObject{chartType: ChartRadarFilled, series: series, options: DefaultChartOptions()}
+	xmlStr = chart.generateChartXML()
+	if !strings.Contains(xmlStr, `<c:radarStyle val="filled"/>`) || strings.Contains(xmlStr, "<c:marker>") {
+		t.Error("填充雷达图应该使用 filled 样式且没有标记点")
+	}
+}
+
+// TestStockChart 测试股价图
+func TestStockChart(t *testing.T) {
+	labels := []string{"周一", "周二"}
+	opts := DefaultChartOptions()
+	opts.UpColor = "FF0000"
+	chart := &chartObject{chartType: ChartStockOHLC, series: []ChartSeries{
+		{Name: "开盘", Labels: labels, Values: []float64{10, 11}},
+		{Name: "最高", Labels: labels, Values: []float64{12, 13}},
+		{Name: "最低", Labels: labels, Values: []float64{9, 10}},
+		{Name: "收盘", Labels: labels, Values: []float64{11, 10}},
+	}, options: opts}
+	xmlStr := chart.generateChartXML()
+
+	if strings.Count(xmlStr, `<c:ser>`) != 4 || strings.Count(xmlStr, `<a:ln w="19050"><a:noFill/></a:ln>`) != 4 {
+		t.Error("股价图的四个系列都不应该画线")
+	}
+	for _, s := range []string{
+		`<c:stockChart><c:ser>`,
+		`<c:hiLowLines>`,
+		`<c:upBars><c:spPr><a:solidFill><a:srgbClr val="FF0000"/></a:solidFill></c:spPr></c:upBars>`,
+		`<c:downBars><c:spPr><a:solidFill><a:srgbClr val="00B050"/></a:solidFill></c:spPr></c:downBars>`,
+		`<c:axId val="1"/><c:axId val="2"/></c:stockChart><c:catAx>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("K线图应该包含 %s", s)
+		}
+	}
+
+	// 高低收盘图没有涨跌柱，收盘价显示为短横线
+	chart = &chartObject{chartType: ChartStockHLC, series: chart.series[1:], options: DefaultChartOptions()}
+	xmlStr = chart.generateChartXML()
+	if strings.Contains(xmlStr, "<c:upDownBars>") || strings.Count(xmlStr, `<c:symbol val="dash"/>`) != 1 {
+		t.Error("高低收盘图应该只在收盘价显示标记")
+	}
+
+	// 系列数量不符时不添加图表，保存时返回错误
+	pres := New()
+	slide := pres.AddSlide()
+	slide.AddChart(ChartStockHLC, chart.series, DefaultChartOptions())
+	if _, err := pres.ToBytes(); err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	slide.AddChart(ChartStockHLC, chart.series[:1], DefaultChartOptions())
+	if len(slide.objects) != 1 {
+		t.Error("系列数量不符的股价图不应该添加")
+	}
+	if _, err := pres.ToBytes(); err == nil || !strings.Contains(err.Error(), "需要 3 个系列") {
+		t.Errorf("系列数量不符时 ToBytes() 应该返回错误，实际为 %v", err)
+	}
+}
+
+// TestDoughnutRings 测试多系列环形图
+func TestDoughnutRings(t *testing.T) {
+	pres := New()
+	pres.AddSlide().AddChart(ChartDoughnut, []ChartSeries{
+		{Name: "2023", Labels: []string{"A", "B"}, Values: []float64{1, 2}},
+		{Name: "2024", Labels: []string{"A", "B"}, Values: []float64{3, 4}},
+	}, DefaultChartOptions())
+	chart := pres.slides[0].objects[0].(*chartObject)
+	xmlStr := chart.generateChartXML()
+
+	if strings.Count(xmlStr, "<c:ser>") != 2 {
+		t.Error("环形图的每个系列应该是一个圆环")
+	}
+	if !strings.Contains(xmlStr, `<c:f>Sheet1!$C$2:$C$3</c:f>`) {
+		t.Error("第二个圆环应该引用工作表C列")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	checkWellFormed(t, readZipParts(t, data))
+}
+
+// TestChartTrendlines 测试趋势线
+func TestChartTrendlines(t *testing.T) {
+	chart := &chartObject{chartType: ChartLine, series: []ChartSeries{{
+		Name: "销量", Labels: []string{"1", "2", "3", "4"}, Values: []float64{1, 3, 2, 5},
+		Trendlines: []Trendline{
+			{DisplayRSquared: true, DisplayEquation: true, Forward: 2},
+			{Type: TrendlineMovingAverage, Period: 3, DisplayEquation: true},
+			{Type: TrendlinePolynomial, Order: 9, Color: "00FF00", Style: BorderSolid},
+			{Type: TrendlineExponential, Name: "指数趋势"},
+		},
+	}}, options: DefaultChartOptions()}
+	xmlStr := chart.generateChartXML()
+
+	for _, s := range []string{
+		`<c:trendlineType val="linear"/><c:forward val="2"/><c:dispRSqr val="1"/><c:dispEq val="1"/><c:trendlineLbl>`,
+		`<c:trendlineType val="movingAvg"/><c:period val="3"/><c:dispRSqr val="0"/><c:dispEq val="0"/></c:trendline>`,
+		`<a:srgbClr val="00FF00"/></a:solidFill><a:prstDash val="solid"/></a:ln></c:spPr><c:trendlineType val="poly"/><c:order val="6"/>`,
+		`<c:trendline><c:name>指数趋势</c:name>`,
+		`<a:schemeClr val="accent1"/></a:solidFill><a:prstDash val="sysDot"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("应该包含 %s", s)
+		}
+	}
+	// 趋势线位于标记点之后、类别数据之前
+	if strings.Index(xmlStr, "<c:trendline>") < strings.Index(xmlStr, "</c:marker>") || strings.Index(xmlStr, "</c:trendline><c:cat>") < 0 {
+		t.Error("趋势线的位置错误")
+	}
+
+	// 雷达图的系列不支持趋势线
+	chart.chartType = ChartRadar
+	if strings.Contains(chart.generateChartXML(), "<c:trendline>") {
+		t.Error("雷达图不应该生成趋势线")
+	}
+}
+
+// TestChartErrorBars 测试误差线
+func TestChartErrorBars(t *testing.T) {
+	tests := []struct {
+		name     string
+		bars     ErrorBars
+		contains []string
+	}{
+		{"固定值", ErrorBars{Value: 2}, []string{`<c:errBarType val="both"/><c:errValType val="fixedVal"/><c:noEndCap val="0"/><c:val val="2"/>`}},
+		{"百分比", ErrorBars{Type: ErrorBarPercentage}, []string{`<c:errValType val="percentage"/><c:noEndCap val="0"/><c:val val="5"/>`}},
+		{"标准偏差", ErrorBars{Type: ErrorBarStdDev, NoEndCap: true}, []string{`<c:errValType val="stdDev"/><c:noEndCap val="1"/><c:val val="1"/>`}},
+		{"自定义", ErrorBars{Type: ErrorBarCustom, Plus: []float64{1, 2}, Minus: []float64{0.5, 0.25}}, []string{
+			`<c:errValType val="cust"/>`,
+			`<c:plus><c:numLit><c:formatCode>General</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>1</c:v></c:pt>`,
+			`<c:minus><c:numLit><c:formatCode>General</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>0.5</c:v></c:pt><c:pt idx="1"><c:v>0.25</c:v></c:pt></c:numLit></c:minus><c:spPr>`,
+		}},
+		{"只有正偏差", ErrorBars{Type: ErrorBarCustom, Plus: []float64{1}}, []string{`<c:errBarType val="plus"/>`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars := tt.bars
+			chart := &chartObject{chartType: ChartBar, series: []ChartSeries{{Name: "A", Labels: []string{"x", "y"}, Values: []float64{3, 4}, ErrorBars: &bars}}, options: DefaultChartOptions()}
+			xmlStr := chart.generateChartXML()
+			for _, s := range tt.contains {
+				if !strings.Contains(xmlStr, s) {
+					t.Errorf("应该包含 %s", s)
+				}
+			}
+			if strings.Contains(xmlStr, "<c:errDir") {
+				t.Error("柱状图的误差线不应该指定方向")
+			}
+		})
+	}
+
+	// 散点图的误差线为Y方向
+	chart := &chartObject{chartType: ChartScatter, series: []ChartSeries{{Name: "A", Values: []float64{1, 2}, ErrorBars: &ErrorBars{Value: 1}}}, options: DefaultChartOptions()}
+	if !strings.Contains(chart.generateChartXML(), `<c:errBars><c:errDir val="y"/>`) {
+		t.Error("散点图的误差线应该指定Y方向")
+	}
+}
+
+// TestChartReferenceLines 测试参考线
+func TestChartReferenceLines(t *testing.T) {
+	opts := DefaultChartOptions()
+	opts.ShowValues = true
+	opts.ReferenceLines = []ReferenceLine{{Value: 150, Label: "目标"}}
+	series := []ChartSeries{{Name: "收入", Labels: []string{"一月", "二月", "三月"}, Values: []float64{100, 160, 140}}}
+
+	pres := New()
+	pres.AddSlide().AddChart(ChartBar, series, opts)
+	if len(series) != 1 {
+		t.Fatal("不应该修改传入的系列")
+	}
+	chart := pres.slides[0].objects[0].(*chartObject)
+	xmlStr := chart.generateChartXML()
+
+	line := xmlStr[strings.Index(xmlStr, "<c:lineChart>"):strings.Index(xmlStr, "</c:lineChart>")]
+	for _, s := range []string{
+		`<c:v>目标</c:v>`,
+		`<a:srgbClr val="FF0000"/></a:solidFill><a:prstDash val="dash"/>`,
+		`<c:marker><c:symbol val="none"/></c:marker><c:dLbls><c:delete val="1"/></c:dLbls>`,
+		`<c:f>Sheet1!$C$2:$C$4</c:f><c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="3"/><c:pt idx="0"><c:v>150</c:v></c:pt><c:pt idx="1"><c:v>150</c:v></c:pt><c:pt idx="2"><c:v>150</c:v></c:pt>`,
+		`<c:axId val="1"/><c:axId val="2"/>`,
+	} {
+		if !strings.Contains(line, s) {
+			t.Errorf("参考线应该包含 %s", s)
+		}
+	}
+
+	// 参考线的数据写入工作簿
+	rows := chart.worksheetRows()
+	if rows[0][2].value != "目标" || rows[3][2].value != "150" {
+		t.Error("工作簿中缺少参考线数据")
+	}
+
+	// 散点图的参考线从最小X值画到最大X值
+	opts.ShowValues = false
+	scatter := &chartObject{chartType: ChartScatter, series: withReferenceLines(ChartScatter, []ChartSeries{
+		{Name: "A", XValues: []float64{3, 1, 8}, Values: []float64{1, 2, 3}},
+	}, opts.ReferenceLines), options: opts}
+	xmlStr = scatter.generateChartXML()
+	if !strings.Contains(xmlStr, `<c:xVal><c:numRef><c:f>Sheet1!$C$2:$C$3</c:f><c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>1</c:v></c:pt><c:pt idx="1"><c:v>8</c:v></c:pt>`) {
+		t.Error("散点图参考线的X值错误")
+	}
+
+	// 饼图忽略参考线
+	if len(withReferenceLines(ChartPie, series, opts.ReferenceLines)) != 1 {
+		t.Error("饼图不应该添加参考线")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	checkWellFormed(t, readZipParts(t, data))
+}
+
+// TestChartDataPoints 测试单个数据点的格式
+func TestChartDataPoints(t *testing.T) {
+	// 饼图每个扇区一个 c:dPt
+	pie := &chartObject{chartType: ChartPie, series: []ChartSeries{{
+		Labels: []string{"A", "B", "C"}, Values: []float64{1, 2, 3},
+		Points: []DataPoint{{Index: 1, Explode: 20}, {Index: 2, Color: "FF0000", Label: "最大"}},
+	}}, options: DefaultChartOptions()}
+	xmlStr := pie.generateChartXML()
+	if strings.Count(xmlStr, "<c:dPt>") != 3 {
+		t.Errorf("应该有3个数据点，实际为 %d", strings.Count(xmlStr, "<c:dPt>"))
+	}
+	for _, s := range []string{
+		`<c:dPt><c:idx val="0"/><c:spPr><a:solidFill><a:schemeClr val="accent1"/></a:solidFill></c:spPr></c:dPt>`,
+		`<c:dPt><c:idx val="1"/><c:explosion val="20"/><c:spPr><a:solidFill><a:schemeClr val="accent2"/></a:solidFill></c:spPr></c:dPt>`,
+		`<c:dPt><c:idx val="2"/><c:spPr><a:solidFill><a:srgbClr val="FF0000"/></a:solidFill></c:spPr></c:dPt>`,
+		`<c:dLbls><c:dLbl><c:idx val="2"/><c:tx><c:rich><a:bodyPr/><a:lstStyle/><a:p><a:r><a:rPr lang="zh-CN"/><a:t>最大</a:t></a:r></a:p></c:rich></c:tx>`,
+		`</c:dLbl><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="1"/><c:showSerName val="0"/><c:showPercent val="1"/></c:dLbls><c:cat>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("饼图应该包含 %s", s)
+		}
+	}
+
+	// 柱状图只为设置了格式的数据点生成 c:dPt
+	bar := &chartObject{chartType: ChartBar, series: []ChartSeries{{
+		Name: "收入", Labels: []string{"一月", "二月", "三月"}, Values: []float64{1, 2, 3},
+		Points: []DataPoint{{Index: 2, Color: "C00000"}, {Index: 0, Pattern: PatternDiagonalUp}},
+	}}, options: DefaultChartOptions()}
+	xmlStr = bar.generateChartXML()
+	if strings.Count(xmlStr, "<c:dPt>") != 2 {
+		t.Error("柱状图应该只有两个数据点格式")
+	}
+	for _, s := range []string{
+		`<c:dPt><c:idx val="0"/><c:invertIfNegative val="0"/><c:spPr><a:pattFill prst="upDiag"><a:fgClr><a:schemeClr val="accent1"/></a:fgClr><a:bgClr><a:srgbClr val="FFFFFF"/></a:bgClr></a:pattFill></c:spPr></c:dPt>`,
+		`<c:dPt><c:idx val="2"/><c:invertIfNegative val="0"/><c:spPr><a:solidFill><a:srgbClr val="C00000"/></a:solidFill></c:spPr></c:dPt>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("柱状图应该包含 %s", s)
+		}
+	}
+	if strings.Contains(xmlStr, "<c:dLbls>") {
+		t.Error("没有标签时不应该生成数据标签")
+	}
+
+	// 折线图改变标记点颜色，自定义标签在未显示数据标签时也显示
+	line := &chartObject{chartType: ChartLine, series: []ChartSeries{{
+		Name: "A", Labels: []string{"x", "y"}, Values: []float64{1, 2},
+		Points: []DataPoint{{Index: 1, Color: "00B050", Label: "本月"}},
+	}}, options: DefaultChartOptions()}
+	xmlStr = line.generateChartXML()
+	for _, s := range []string{
+		`<c:dPt><c:idx val="1"/><c:marker><c:symbol val="circle"/><c:size val="7"/><c:spPr><a:solidFill><a:srgbClr val="00B050"/></a:solidFill></c:spPr></c:marker></c:dPt><c:dLbls><c:dLbl>`,
+		`<a:t>本月</a:t>`,
+		`</c:dLbl><c:showLegendKey val="0"/><c:showVal val="0"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("折线图应该包含 %s", s)
+		}
+	}
+
+	// 数据点的数字格式，只在显示数据标签时生成
+	opts := DefaultChartOptions()
+	opts.ShowValues = true
+	opts.LabelFormat = "#,##0"
+	formatted := &chartObject{chartType: ChartBar, series: []ChartSeries{{
+		Name: "增长", Labels: []string{"一月", "二月"}, Values: []float64{0.1, 0.25},
+		Points: []DataPoint{{Index: 1, NumberFormat: "0.0%"}, {Index: 0, Label: "基期", NumberFormat: "0%"}},
+	}}, options: opts}
+	xmlStr = formatted.generateChartXML()
+	for _, s := range []string{
+		`<c:dLbl><c:idx val="1"/><c:numFmt formatCode="0.0%" sourceLinked="0"/><c:showLegendKey val="0"/><c:showVal val="1"/>`,
+		`<a:t>基期</a:t></a:r></a:p></c:rich></c:tx><c:numFmt formatCode="0%" sourceLinked="0"/><c:showLegendKey val="0"/>`,
+		`</c:dLbl><c:numFmt formatCode="#,##0" sourceLinked="0"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("数据点标签应该包含 %s", s)
+		}
+	}
+	formatted.options.ShowValues = false
+	formatted.series[0].Points = formatted.series[0].Points[:1]
+	if strings.Contains(formatted.generateChartXML(), "<c:dLbl>") {
+		t.Error("未显示数据标签时，只设置了数字格式的数据点不应该生成标签")
+	}
+	pie.series[0].Points = append(pie.series[0].Points, DataPoint{Index: 0, NumberFormat: "0.00"})
+	if !strings.Contains(pie.generateChartXML(), `<c:dLbl><c:idx val="0"/><c:numFmt formatCode="0.00" sourceLinked="0"/><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="1"/>`) {
+		t.Error("饼图数据点的数字格式应该沿用饼图的标签设置")
+	}
+
+	pres := New()
+	pres.AddSlide().AddChart(ChartDoughnut, pie.series, DefaultChartOptions())
+	pres.AddSlide().AddChart(ChartBar, formatted.series, opts)
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	checkWellFormed(t, readZipParts(t, data))
+}
+
+// TestChartStyle 测试图表整体样式
+func TestChartStyle(t *testing.T) {
+	// 默认样式与原有输出一致
+	chart := &chartObject{chartType: ChartBar, series: []ChartSeries{{Name: "A", Labels: []string{"x"}, Values: []float64{1}}}, options: DefaultChartOptions()}
+	chart.options.Title = "标题"
+	xmlStr := chart.generateChartXML()
+	for _, s := range []string{
+		`<c:lang val="zh-CN"/><c:roundedCorners val="0"/>`,
+		`<a:pPr><a:defRPr sz="1400" b="1"/></a:pPr><a:r><a:rPr lang="zh-CN" sz="1400" b="1"/>`,
+		`<c:legend><c:legendPos val="r"/><c:overlay val="0"/></c:legend>`,
+		`</c:chart><c:externalData`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("默认样式应该包含 %s", s)
+		}
+	}
+
+	chart.options.Style = &ChartStyle{
+		FontFace:        "微软雅黑",
+		FontSize:        10,
+		FontColor:       "333333",
+		Language:        "en-US",
+		Background:      "F2F2F2",
+		BorderColor:     "none",
+		RoundedCorners:  true,
+		PlotBackground:  "FFFFFF",
+		PlotBorderColor: "D9D9D9",
+		PlotBorderWidth: 1,
+		TitleFontSize:   18,
+		LegendFontSize:  8,
+		LegendOverlay:   true,
+	}
+	chart.options.ValueAxis.Title = "金额"
+	xmlStr = chart.generateChartXML()
+	for _, s := range []string{
+		`<c:lang val="en-US"/><c:roundedCorners val="1"/>`,
+		// 标题沿用整体字体和颜色，默认加粗
+		`<a:defRPr sz="1800" b="1"><a:solidFill><a:srgbClr val="333333"/></a:solidFill><a:latin typeface="微软雅黑"/><a:ea typeface="微软雅黑"/></a:defRPr></a:pPr><a:r><a:rPr lang="en-US" sz="1800" b="1"/>`,
+		`<c:spPr><a:solidFill><a:srgbClr val="FFFFFF"/></a:solidFill><a:ln w="12700"><a:solidFill><a:srgbClr val="D9D9D9"/></a:solidFill></a:ln></c:spPr></c:plotArea>`,
+		`<c:overlay val="1"/><c:txPr><a:bodyPr/><a:lstStyle/><a:p><a:pPr><a:defRPr sz="800" b="0"/></a:pPr><a:endParaRPr lang="en-US"/></a:p></c:txPr></c:legend>`,
+		`</c:chart><c:spPr><a:solidFill><a:srgbClr val="F2F2F2"/></a:solidFill><a:ln><a:noFill/></a:ln></c:spPr><c:txPr><a:bodyPr/><a:lstStyle/><a:p><a:pPr><a:defRPr sz="1000" b="0">`,
+		`<a:rPr lang="en-US"/><a:t>金额</a:t>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("自定义样式应该包含 %s", s)
+		}
+	}
+	if strings.Contains(xmlStr, "zh-CN") {
+		t.Error("所有文字应该使用样式中的语言")
+	}
+
+	// 显式取消标题加粗
+	chart.options.Style.TitleBold = Bool(false)
+	if !strings.Contains(chart.generateChartXML(), `<a:rPr lang="en-US" sz="1800" b="0"/><a:t>标题</a:t>`) {
+		t.Error("TitleBold 为 false 时标题不应该加粗")
+	}
+
+	// 预设样式
+	for name, st := range map[string]ChartStyle{
+		"简洁": MinimalChartStyle(),
+		"深色": DarkChartStyle(),
+		"卡片": CardChartStyle(),
+	} {
+		pres := New()
+		opts := DefaultChartOptions()
+		opts.Title = name
+		opts.Style = &st
+		pres.AddSlide().AddChart(ChartLine, chart.series, opts)
+		data, err := pres.ToBytes()
+		if err != nil {
+			t.Fatalf("%s样式 ToBytes() 失败: %v", name, err)
+		}
+		checkWellFormed(t, readZipParts(t, data))
+	}
+	card := CardChartStyle()
+	chart.options.Style = &card
+	if !strings.Contains(chart.generateChartXML(), `<c:roundedCorners val="1"/>`) {
+		t.Error("卡片样式应该有圆角")
+	}
+}
+
+// TestDateAxis 测试日期类别和日期轴
+func TestDateAxis(t *testing.T) {
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local),
+	}
+	opts := DefaultChartOptions()
+	opts.CategoryAxis = AxisOptions{BaseUnit: TimeUnitDays, MajorUnit: 1, MajorTimeUnit: TimeUnitMonths}
+	opts.ReferenceLines = []ReferenceLine{{Value: 5, Label: "目标"}}
+	pres := New()
+	pres.AddSlide().AddChart(ChartLine, []ChartSeries{{Name: "事件数", Dates: dates, Values: []float64{3, 7, 2}}}, opts)
+	chart := pres.slides[0].objects[0].(*chartObject)
+	xmlStr := chart.generateChartXML()
+
+	for _, s := range []string{
+		// 2024-01-01 的序列号为 45292，时间为小数部分
+		`<c:cat><c:numRef><c:f>Sheet1!$A$2:$A$4</c:f><c:numCache><c:formatCode>yyyy-mm-dd</c:formatCode><c:ptCount val="3"/><c:pt idx="0"><c:v>45292</c:v></c:pt><c:pt idx="1"><c:v>45293.5</c:v></c:pt><c:pt idx="2"><c:v>45352</c:v></c:pt>`,
+		`<c:dateAx><c:axId val="1"/>`,
+		`<c:crossAx val="2"/><c:crosses val="autoZero"/><c:auto val="0"/><c:lblOffset val="100"/><c:baseTimeUnit val="days"/><c:majorUnit val="1"/><c:majorTimeUnit val="months"/></c:dateAx>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("应该包含 %s", s)
+		}
+	}
+	if strings.Contains(xmlStr, "<c:catAx>") {
+		t.Error("日期类别不应该使用类别轴")
+	}
+	// 参考线沿用日期类别
+	if strings.Count(xmlStr, `<c:f>Sheet1!$A$2:$A$4</c:f>`) != 2 {
+		t.Error("参考线应该使用相同的日期类别")
+	}
+
+	// 工作簿中的日期为带日期格式的数值
+	rows := chart.worksheetRows()
+	if cell := rows[2][0]; !cell.numeric || cell.value != "45293.5" || cell.format != "yyyy-mm-dd" {
+		t.Errorf("工作簿中的日期错误: %+v", cell)
+	}
+
+	// 只设置了类别标签的系列也引用A列的日期，次类别轴同样为日期轴
+	combo := &chartObject{chartType: ChartBar, series: []ChartSeries{
+		{Name: "事件数", Dates: dates, Values: []float64{3, 7, 2}},
+		{Name: "占比", Labels: []string{"甲", "乙"}, Values: []float64{0.1, 0.2}, Type: ChartLine, SecondaryAxis: true},
+	}, options: DefaultChartOptions()}
+	xmlStr = combo.generateChartXML()
+	if strings.Count(xmlStr, `<c:cat><c:numRef><c:f>Sheet1!$A$2:$A$4</c:f>`) != 2 || strings.Contains(xmlStr, "<c:strRef><c:f>Sheet1!$A") {
+		t.Errorf("日期图表的所有系列都应该引用相同的日期类别: %s", xmlStr)
+	}
+	if strings.Count(xmlStr, "<c:dateAx>") != 2 || strings.Contains(xmlStr, "<c:catAx>") {
+		t.Error("日期图表的次类别轴应该为日期轴")
+	}
+
+	// 按月的基本单位使用年月格式
+	chart.options.CategoryAxis.BaseUnit = TimeUnitMonths
+	if chart.dateFormat() != "yyyy-mm" {
+		t.Error("按月的日期格式应该为 yyyy-mm")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	checkWellFormed(t, readZipParts(t, data))
+}
@@ -85,6 +85,8 @@ const (
 	BorderDot BorderStyle = "dot"
 	// BorderNone 无边框
 	BorderNone BorderStyle = "none"
+	// BorderDouble 双线，仅用于表格边框
+	BorderDouble BorderStyle = "double"
 )
 
 // OutputType 定义输出类型
@@ -98,3 +100,234 @@ const (
 	// OutputWriter 输出到io.Writer
 	OutputWriter OutputType = "writer"
 )
+
+// LinkAction 定义点击跳转动作
+type LinkAction string
+
+const (
+	// LinkNextSlide 跳转到下一张幻灯片
+	LinkNextSlide LinkAction = "nextslide"
+	// LinkPreviousSlide 跳转到上一张幻灯片
+	LinkPreviousSlide LinkAction = "previousslide"
+	// LinkFirstSlide 跳转到第一张幻灯片
+	LinkFirstSlide LinkAction = "firstslide"
+	// LinkLastSlide 跳转到最后一张幻灯片
+	LinkLastSlide LinkAction = "lastslide"
+)
+
+// TransitionType 定义幻灯片切换效果
+type TransitionType string
+
+const (
+	// TransitionNone 无切换效果
+	TransitionNone TransitionType = "none"
+	// TransitionFade 淡入淡出
+	TransitionFade TransitionType = "fade"
+	// TransitionPush 推入
+	TransitionPush TransitionType = "push"
+	// TransitionWipe 擦除
+	TransitionWipe TransitionType = "wipe"
+	// TransitionSplit 分割
+	TransitionSplit TransitionType = "split"
+	// TransitionCover 覆盖
+	TransitionCover TransitionType = "cover"
+	// TransitionZoom 缩放
+	TransitionZoom TransitionType = "zoom"
+	// TransitionMorph 平滑（PowerPoint 2019及以上，旧版本显示为淡入淡出）
+	TransitionMorph TransitionType = "morph"
+)
+
+// TransitionDirection 定义切换效果的方向
+type TransitionDirection string
+
+const (
+	// DirectionLeft 向左
+	DirectionLeft TransitionDirection = "l"
+	// DirectionRight 向右
+	DirectionRight TransitionDirection = "r"
+	// DirectionUp 向上
+	DirectionUp TransitionDirection = "u"
+	// DirectionDown 向下
+	DirectionDown TransitionDirection = "d"
+	// DirectionIn 向内（分割、缩放）
+	DirectionIn TransitionDirection = "in"
+	// DirectionOut 向外（分割、缩放）
+	DirectionOut TransitionDirection = "out"
+)
+
+// AnimationEffect 定义对象动画效果
+type AnimationEffect string
+
+const (
+	// AnimationAppear 出现
+	AnimationAppear AnimationEffect = "appear"
+	// AnimationFade 淡化
+	AnimationFade AnimationEffect = "fade"
+	// AnimationFlyIn 飞入（退出时为飞出）
+	AnimationFlyIn AnimationEffect = "fly"
+	// AnimationZoom 缩放
+	AnimationZoom AnimationEffect = "zoom"
+	// AnimationWipe 擦除
+	AnimationWipe AnimationEffect = "wipe"
+	// AnimationPulse 脉冲（强调效果）
+	AnimationPulse AnimationEffect = "pulse"
+)
+
+// AnimationTrigger 定义动画的开始方式
+type AnimationTrigger string
+
+const (
+	// TriggerOnClick 单击时
+	TriggerOnClick AnimationTrigger = "click"
+	// TriggerWithPrevious 与上一动画同时
+	TriggerWithPrevious AnimationTrigger = "with"
+	// TriggerAfterPrevious 上一动画之后
+	TriggerAfterPrevious AnimationTrigger = "after"
+)
+
+// Gridlines 定义图表坐标轴的网格线
+type Gridlines string
+
+const (
+	// GridlinesDefault 默认（数值轴显示主要网格线，类别轴不显示）
+	GridlinesDefault Gridlines = ""
+	// GridlinesNone 不显示网格线
+	GridlinesNone Gridlines = "none"
+	// GridlinesMajor 主要网格线
+	GridlinesMajor Gridlines = "major"
+	// GridlinesMinor 次要网格线
+	GridlinesMinor Gridlines = "minor"
+	// GridlinesBoth 主要和次要网格线
+	GridlinesBoth Gridlines = "both"
+)
+
+// TrendlineType 定义图表趋势线类型
+type TrendlineType string
+
+const (
+	// TrendlineLinear 线性
+	TrendlineLinear TrendlineType = "linear"
+	// TrendlineExponential 指数
+	TrendlineExponential TrendlineType = "exp"
+	// TrendlineLogarithmic 对数
+	TrendlineLogarithmic TrendlineType = "log"
+	// TrendlinePower 幂
+	TrendlinePower TrendlineType = "power"
+	// TrendlinePolynomial 多项式
+	TrendlinePolynomial TrendlineType = "poly"
+	// TrendlineMovingAverage 移动平均
+	TrendlineMovingAverage TrendlineType = "movingAvg"
+)
+
+// ErrorBarType 定义误差线的误差量类型
+type ErrorBarType string
+
+const (
+	// ErrorBarFixed 固定值
+	ErrorBarFixed ErrorBarType = "fixedVal"
+	// ErrorBarPercentage 百分比
+	ErrorBarPercentage ErrorBarType = "percentage"
+	// ErrorBarStdDev 标准偏差
+	ErrorBarStdDev ErrorBarType = "stdDev"
+	// ErrorBarStdErr 标准误差
+	ErrorBarStdErr ErrorBarType = "stdErr"
+	// ErrorBarCustom 自定义，每个数据点使用 Plus/Minus 中的值
+	ErrorBarCustom ErrorBarType = "cust"
+)
+
+// FillPattern 定义图案填充的样式
+type FillPattern string
+
+const (
+	// PatternPercent10 10%点
+	PatternPercent10 FillPattern = "pct10"
+	// PatternPercent25 25%点
+	PatternPercent25 FillPattern = "pct25"
+	// PatternPercent50 50%点
+	PatternPercent50 FillPattern = "pct50"
+	// PatternHorizontal 横线
+	PatternHorizontal FillPattern = "horz"
+	// PatternVertical 竖线
+	PatternVertical FillPattern = "vert"
+	// PatternDiagonalUp 上斜线
+	PatternDiagonalUp FillPattern = "upDiag"
+	// PatternDiagonalDown 下斜线
+	PatternDiagonalDown FillPattern = "dnDiag"
+	// PatternWideDiagonalUp 宽上斜线
+	PatternWideDiagonalUp FillPattern = "wdUpDiag"
+	// PatternCross 十字网格
+	PatternCross FillPattern = "cross"
+	// PatternDiagonalCross 斜十字网格
+	PatternDiagonalCross FillPattern = "diagCross"
+	// PatternSmallGrid 小网格
+	PatternSmallGrid FillPattern = "smGrid"
+	// PatternDotGrid 点网格
+	PatternDotGrid FillPattern = "dotGrid"
+)
+
+// TimeUnit 定义日期轴的时间单位
+type TimeUnit string
+
+const (
+	// TimeUnitDays 天
+	TimeUnitDays TimeUnit = "days"
+	// TimeUnitMonths 月
+	TimeUnitMonths TimeUnit = "months"
+	// TimeUnitYears 年
+	TimeUnitYears TimeUnit = "years"
+)
+
+// TableStyle 定义表格样式，值为样式的 GUID
+// 内置样式由 PowerPoint 提供；自定义样式通过 Presentation.AddTableStyle 添加
+type TableStyle string
+
+const (
+	// TableStyleNoStyleNoGrid 无样式，无网格
+	TableStyleNoStyleNoGrid TableStyle = "{2D5ABB26-0587-4C30-8999-92F81FD0307C}"
+	// TableStyleNoStyleTableGrid 无样式，网格型
+	TableStyleNoStyleTableGrid TableStyle = "{5940675A-B579-460E-94D1-54222C63F5DA}"
+	// TableStyleThemed1Accent1 主题样式1-强调1
+	TableStyleThemed1Accent1 TableStyle = "{3C2FFA5D-87B4-456A-9821-1D502468CF0F}"
+	// TableStyleLight1Accent1 浅色样式1-强调1
+	TableStyleLight1Accent1 TableStyle = "{3B4B98B0-60AC-42C2-AFA5-B58CD77FA1E5}"
+	// TableStyleLight2Accent1 浅色样式2-强调1
+	TableStyleLight2Accent1 TableStyle = "{69012ECD-51FC-41F1-AA8D-1B2483CD663E}"
+	// TableStyleLight3Accent1 浅色样式3-强调1
+	TableStyleLight3Accent1 TableStyle = "{BC89EF96-8CEA-46FF-86C4-4CE0E7609802}"
+	// TableStyleMedium1Accent1 中度样式1-强调1
+	TableStyleMedium1Accent1 TableStyle = "{B301B821-A1FF-4177-AEE7-76D212191A09}"
+	// TableStyleMedium2 中度样式2
+	TableStyleMedium2 TableStyle = "{073A0DAA-6AF3-43AB-8588-CEC1D06C72B9}"
+	// TableStyleMedium2Accent1 中度样式2-强调1（默认样式）
+	TableStyleMedium2Accent1 TableStyle = "{5C22544A-7EE6-4342-B048-85BDC9FD1C3A}"
+	// TableStyleMedium2Accent2 中度样式2-强调2
+	TableStyleMedium2Accent2 TableStyle = "{21E4AEA4-8DFA-4A89-87EB-49C32662AFE8}"
+	// TableStyleMedium2Accent3 中度样式2-强调3
+	TableStyleMedium2Accent3 TableStyle = "{F5AB1C69-6EDB-4FF4-983F-18BD219EF322}"
+	// TableStyleMedium2Accent4 中度样式2-强调4
+	TableStyleMedium2Accent4 TableStyle = "{00A15C55-8517-42AA-B614-E9B94910E393}"
+	// TableStyleMedium2Accent5 中度样式2-强调5
+	TableStyleMedium2Accent5 TableStyle = "{7DF18680-E054-41AD-8BC1-D1AEF772440D}"
+	// TableStyleMedium2Accent6 中度样式2-强调6
+	TableStyleMedium2Accent6 TableStyle = "{93296810-A885-4BE3-A3E7-6D5BEEA58F35}"
+	// TableStyleMedium3Accent1 中度样式3-强调1
+	TableStyleMedium3Accent1 TableStyle = "{6E25E649-3F16-4E02-A733-19D2CDBF48F0}"
+	// TableStyleMedium4Accent1 中度样式4-强调1
+	TableStyleMedium4Accent1 TableStyle = "{69CF1AB2-1976-4502-BF36-3FF5EA218861}"
+)
+
+// TextDirection 定义文字方向
+type TextDirection string
+
+const (
+	// TextHorizontal 横排
+	TextHorizontal TextDirection = "horz"
+	// TextRotate90 所有文字顺时针旋转90度
+	TextRotate90 TextDirection = "vert"
+	// TextRotate270 所有文字顺时针旋转270度
+	TextRotate270 TextDirection = "vert270"
+	// TextStacked 字母逐个竖向堆叠
+	TextStacked TextDirection = "wordArtVert"
+	// TextEastAsianVertical 中文竖排，西文旋转90度
+	TextEastAsianVertical TextDirection = "eaVert"
+)
@@ -0,0 +1,139 @@
+package genppt
+
+import (
+	"net/url"
+	"strings"
+)
+
+// slideLink 幻灯片中需要关系的链接
+type slideLink struct {
+	link *Hyperlink
+	rID  string
+}
+
+// slideLinks 幻灯片中的链接及其关系ID，生成幻灯片和关系文件时使用同一份
+type slideLinks struct {
+	list []slideLink
+	ids  map[*Hyperlink]string
+}
+
+// collectLinks 按对象顺序收集幻灯片中的链接并分配关系ID（rIdL1、rIdL2...）
+// 同一个 *Hyperlink 只分配一次，不需要关系的跳转动作和无效的幻灯片序号不分配
+func (s *Slide) collectLinks() slideLinks {
+	links := slideLinks{ids: make(map[*Hyperlink]string)}
+	add := func(link *Hyperlink) {
+		if link == nil || links.ids[link] != "" || !s.linkNeedsRel(link) {
+			return
+		}
+		rID := "rIdL" + itoa(len(links.list)+1)
+		links.ids[link] = rID
+		links.list = append(links.list, slideLink{link: link, rID: rID})
+	}
+
+	for _, obj := range s.objects {
+		switch o := obj.(type) {
+		case *textObject:
+			add(o.options.Link)
+			for _, para := range o.paragraphs {
+				for _, run := range para.Runs {
+					add(run.Link)
+				}
+			}
+		case *shapeObject:
+			add(o.options.Link)
+		case *imageObject:
+			add(o.options.Link)
+		case *tableObject:
+			for _, row := range o.rows {
+				for _, cell := range row {
+					for _, para := range cell.Paragraphs {
+						for _, run := range para.Runs {
+							add(run.Link)
+						}
+					}
+				}
+			}
+		}
+	}
+	return links
+}
+
+// linkNeedsRel 链接是否需要关系（外部链接和跳转到指定幻灯片）
+func (s *Slide) linkNeedsRel(link *Hyperlink) bool {
+	if link.URL != "" || link.Email != "" {
+		return true
+	}
+	return link.Slide > 0 && link.Slide <= len(s.presentation.slides)
+}
+
+// linkTarget 返回外部链接的地址
+func (link *Hyperlink) linkTarget() string {
+	if link.URL != "" {
+		return link.URL
+	}
+	target := "mailto:" + url.PathEscape(link.Email)
+	if link.Subject != "" {
+		// 主题中的 & 和 = 需要转义，空格使用 %20 而不是 +
+		target += "?subject=" + strings.ReplaceAll(url.QueryEscape(link.Subject), "+", "%20")
+	}
+	return target
+}
+
+// hlinkClick 生成点击链接元素 a:hlinkClick，链接无效时返回空字符串
+func (s *Slide) hlinkClick(link *Hyperlink) string {
+	if link == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<a:hlinkClick r:id="`)
+	switch {
+	case link.URL != "" || link.Email != "":
+		rID, ok := s.linkIDs[link]
+		if !ok {
+			return ""
+		}
+		sb.WriteString(rID)
+		sb.WriteString(`"`)
+	case link.Slide > 0:
+		rID, ok := s.linkIDs[link]
+		if !ok {
+			return ""
+		}
+		sb.WriteString(rID)
+		sb.WriteString(`" action="ppaction://hlinksldjump"`)
+	case link.Action != "":
+		sb.WriteString(`" action="ppaction://hlinkshowjump?jump=`)
+		sb.WriteString(string(link.Action))
+		sb.WriteString(`"`)
+	default:
+		return ""
+	}
+	if link.Tooltip != "" {
+		sb.WriteString(` tooltip="`)
+		sb.WriteString(escapeXML(link.Tooltip))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(`/>`)
+	return sb.String()
+}
+
+// writeLinkRels 写入链接关系
+func writeLinkRels(sb *strings.Builder, links slideLinks) {
+	for _, l := range links.list {
+		if l.link.URL != "" || l.link.Email != "" {
+			writeRelationship(sb, packageRel{
+				id:         l.rID,
+				relType:    "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink",
+				target:     l.link.linkTarget(),
+				targetMode: "External",
+			})
+		} else {
+			writeRelationship(sb, packageRel{
+				id:      l.rID,
+				relType: relTypeSlide,
+				target:  "slide" + itoa(l.link.Slide) + ".xml",
+			})
+		}
+	}
+}
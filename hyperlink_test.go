@@ -0,0 +1,94 @@
+package genppt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestHyperlinks 测试文本、形状和图片上的链接
+func TestHyperlinks(t *testing.T) {
+	pres := New()
+	agenda := pres.AddSlide()
+	pres.AddSlide()
+	pres.AddSlide()
+
+	source := &Hyperlink{URL: "https://example.com/report?a=1&b=2", Tooltip: "数据来源"}
+	agenda.AddRichText([]Paragraph{
+		{Runs: []TextRun{{Text: "来源：", Bold: Bool(true)}, {Text: "example.com", Link: source}}},
+		{Runs: []TextRun{{Text: "第三章", Link: &Hyperlink{Slide: 3}}}},
+		{Runs: []TextRun{{Text: "联系我们", Link: &Hyperlink{Email: "a@example.com", Subject: "问题 反馈"}}}},
+		{Runs: []TextRun{{Text: "无效", Link: &Hyperlink{Slide: 9}}}},
+	}, TextOptions{})
+	agenda.AddText("再次引用", TextOptions{Link: source})
+	agenda.AddShape(ShapeArrowRight, ShapeOptions{Link: &Hyperlink{Action: LinkNextSlide}})
+	agenda.AddImage(ImageOptions{Data: []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}, Link: &Hyperlink{Slide: 2}})
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+
+	slideXML := string(parts["ppt/slides/slide1.xml"])
+	expected := []string{
+		`<a:hlinkClick r:id="rIdL1" tooltip="数据来源"/>`,
+		`<a:hlinkClick r:id="rIdL2" action="ppaction://hlinksldjump"/>`,
+		`<a:hlinkClick r:id="rIdL3"/>`,
+		`<a:hlinkClick r:id="" action="ppaction://hlinkshowjump?jump=nextslide"/></p:cNvPr>`,
+		`<a:hlinkClick r:id="rIdL4" action="ppaction://hlinksldjump"/></p:cNvPr>`,
+	}
+	for _, s := range expected {
+		if !strings.Contains(slideXML, s) {
+			t.Errorf("slide1.xml 应该包含 %s", s)
+		}
+	}
+	if strings.Count(slideXML, `r:id="rIdL1"`) != 2 {
+		t.Error("同一个链接应该共用关系")
+	}
+	if strings.Contains(slideXML, "rIdL5") {
+		t.Error("无效的幻灯片序号不应生成链接")
+	}
+
+	rels := string(parts["ppt/slides/_rels/slide1.xml.rels"])
+	expectedRels := []string{
+		`Id="rIdL1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com/report?a=1&amp;b=2" TargetMode="External"`,
+		`Id="rIdL2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slide3.xml"`,
+		`Target="mailto:a@example.com?subject=%E9%97%AE%E9%A2%98%20%E5%8F%8D%E9%A6%88" TargetMode="External"`,
+		`Id="rIdL4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slide2.xml"`,
+	}
+	for _, s := range expectedRels {
+		if !strings.Contains(rels, s) {
+			t.Errorf("slide1.xml.rels 应该包含 %s", s)
+		}
+	}
+
+	// 邮件主题中的 & 和 = 需要转义
+	if target := (&Hyperlink{Email: "a b@example.com", Subject: "Q&A=1"}).linkTarget(); target != "mailto:a%20b@example.com?subject=Q%26A%3D1" {
+		t.Errorf("邮件链接转义不正确: %s", target)
+	}
+
+	// 没有分配关系的外部链接不生成 a:hlinkClick
+	if s := agenda.hlinkClick(&Hyperlink{URL: "https://example.com"}); s != "" {
+		t.Errorf("没有关系ID的链接不应该生成 a:hlinkClick: %s", s)
+	}
+	links := agenda.collectLinks()
+	if len(links.list) != 4 || links.ids[source] != "rIdL1" || len(agenda.collectLinks().list) != 4 {
+		t.Error("多次收集链接应该得到相同的关系ID")
+	}
+
+	// 重新打开后链接关系保留
+	opened, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	out, err := opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	reopened := readZipParts(t, out)
+	if !strings.Contains(string(reopened["ppt/slides/_rels/slide1.xml.rels"]), `Target="slide3.xml"`) {
+		t.Error("重新打开后幻灯片跳转关系丢失")
+	}
+}
@@ -0,0 +1,93 @@
+package genppt
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBuiltinLayouts 测试内置布局及其占位符
+func TestBuiltinLayouts(t *testing.T) {
+	pres := New()
+	pres.AddSlide()
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+
+	expected := map[string][]string{
+		"ppt/slideLayouts/slideLayout1.xml": {`type="blank"`},
+		"ppt/slideLayouts/slideLayout2.xml": {`type="title"`, `<p:ph type="ctrTitle"/>`, `<p:ph type="subTitle" idx="1"/>`},
+		"ppt/slideLayouts/slideLayout3.xml": {`type="obj"`, `<p:ph type="title"/>`, `<p:ph idx="1"/>`},
+		"ppt/slideLayouts/slideLayout4.xml": {`type="twoObj"`, `<p:ph sz="half" idx="1"/>`, `<p:ph sz="half" idx="2"/>`},
+		"ppt/slideMasters/slideMaster1.xml": {`<p:ph type="title"/>`, `<p:ph type="body" idx="1"/>`, `r:id="rId4"`},
+	}
+	for name, contains := range expected {
+		content := string(parts[name])
+		for _, s := range contains {
+			if !strings.Contains(content, s) {
+				t.Errorf("%s 应该包含 %s", name, s)
+			}
+		}
+	}
+	if strings.Contains(string(parts["ppt/slideLayouts/slideLayout1.xml"]), "<p:ph") {
+		t.Error("空白布局不应包含占位符")
+	}
+	if !strings.Contains(string(parts["ppt/slideMasters/_rels/slideMaster1.xml.rels"]), `Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"`) {
+		t.Error("母版的主题关系不正确")
+	}
+	if !strings.Contains(string(parts["[Content_Types].xml"]), "/ppt/slideLayouts/slideLayout4.xml") {
+		t.Error("[Content_Types].xml 缺少布局")
+	}
+}
+
+// TestSlidePlaceholders 测试填充标题和正文占位符
+func TestSlidePlaceholders(t *testing.T) {
+	pres := New()
+	title := pres.AddSlide().SetLayout(LayoutTitle).SetTitle("年度总结").SetBody("2024")
+	content := pres.AddSlide().SetLayout(LayoutTitleContent)
+	content.AddText("脚注", TextOptions{X: 1, Y: 5})
+	content.SetBody("要点一\n要点二").SetTitle("议程")
+	two := pres.AddSlide().SetLayout(LayoutTwoContent).SetTitle("对比").SetColumn(0, "左栏").SetColumn(1, "右栏")
+	two.SetColumn(2, "无效")
+
+	titleXML := title.generateSlide(title.collectLinks())
+	if !strings.Contains(titleXML, `<p:ph type="ctrTitle"/>`) || !strings.Contains(titleXML, `<p:ph type="subTitle" idx="1"/>`) {
+		t.Errorf("标题布局的占位符不正确: %s", titleXML)
+	}
+	if !strings.Contains(title.generateSlideRels(title.collectLinks()), `Target="../slideLayouts/slideLayout2.xml"`) {
+		t.Error("标题幻灯片应该使用标题布局")
+	}
+
+	contentXML := content.generateSlide(content.collectLinks())
+	titleAt := strings.Index(contentXML, "议程")
+	bodyAt := strings.Index(contentXML, "要点一")
+	textAt := strings.Index(contentXML, "脚注")
+	if titleAt < 0 || bodyAt < 0 || textAt < 0 || !(titleAt < bodyAt && bodyAt < textAt) {
+		t.Error("占位符应该按标题、正文的顺序排在其他对象之前")
+	}
+	if strings.Count(contentXML, "<a:p>") < 3 {
+		t.Error("多行正文应该拆分为段落")
+	}
+
+	content.SetTitle("新议程")
+	if len(content.objects) != 3 {
+		t.Errorf("重复设置标题不应新增对象，实际有 %d 个", len(content.objects))
+	}
+
+	twoXML := two.generateSlide(two.collectLinks())
+	if !strings.Contains(twoXML, `<p:ph sz="half" idx="1"/>`) || !strings.Contains(twoXML, `<p:ph sz="half" idx="2"/>`) {
+		t.Errorf("两栏布局的占位符不正确: %s", twoXML)
+	}
+	if strings.Contains(twoXML, "无效") {
+		t.Error("超出范围的栏不应生成")
+	}
+	if !strings.Contains(two.generateSlideRels(two.collectLinks()), `Target="../slideLayouts/slideLayout4.xml"`) {
+		t.Error("两栏幻灯片应该使用两栏布局")
+	}
+
+	if n := pres.ReplaceText("栏", "列"); n != 2 {
+		t.Errorf("应该替换 2 处，实际替换 %d 处", n)
+	}
+}
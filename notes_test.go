@@ -0,0 +1,167 @@
+package genppt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestSpeakerNotes 测试写出演讲者备注
+func TestSpeakerNotes(t *testing.T) {
+	pres := New()
+	pres.AddSlide().SetNotes("第一行\n\n第三行 <重点>")
+	pres.AddSlide()
+	pres.AddSlide().SetNotes("结束语")
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+
+	for _, name := range []string{
+		"ppt/notesMasters/notesMaster1.xml",
+		"ppt/notesMasters/_rels/notesMaster1.xml.rels",
+		"ppt/theme/theme2.xml",
+		"ppt/notesSlides/notesSlide1.xml",
+		"ppt/notesSlides/_rels/notesSlide1.xml.rels",
+		"ppt/notesSlides/notesSlide3.xml",
+	} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("缺少部件: %s", name)
+		}
+	}
+	if _, ok := parts["ppt/notesSlides/notesSlide2.xml"]; ok {
+		t.Error("没有备注的幻灯片不应生成备注页")
+	}
+
+	notes := string(parts["ppt/notesSlides/notesSlide1.xml"])
+	if strings.Count(notes, "<a:p>") != 3 {
+		t.Errorf("多行备注应该生成 3 个段落: %s", notes)
+	}
+	if !strings.Contains(notes, "第三行 &lt;重点&gt;") {
+		t.Error("备注文本没有正确转义")
+	}
+
+	if !strings.Contains(string(parts["ppt/slides/_rels/slide1.xml.rels"]), `Target="../notesSlides/notesSlide1.xml"`) {
+		t.Error("幻灯片缺少备注页关系")
+	}
+	if strings.Contains(string(parts["ppt/slides/_rels/slide2.xml.rels"]), "notesSlide") {
+		t.Error("没有备注的幻灯片不应引用备注页")
+	}
+	if !strings.Contains(string(parts["ppt/notesSlides/_rels/notesSlide3.xml.rels"]), `Target="../slides/slide3.xml"`) {
+		t.Error("备注页应该指回所属幻灯片")
+	}
+
+	presXML := string(parts["ppt/presentation.xml"])
+	if !strings.Contains(presXML, `<p:notesMasterId r:id="rId9"/>`) {
+		t.Errorf("presentation.xml 缺少备注母版: %s", presXML)
+	}
+	if !strings.Contains(string(parts["ppt/_rels/presentation.xml.rels"]), `Id="rId9" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesMaster"`) {
+		t.Error("presentation.xml.rels 缺少备注母版关系")
+	}
+	types := string(parts["[Content_Types].xml"])
+	if !strings.Contains(types, "/ppt/notesSlides/notesSlide3.xml") || !strings.Contains(types, "/ppt/notesMasters/notesMaster1.xml") {
+		t.Error("[Content_Types].xml 缺少备注相关类型")
+	}
+	if !strings.Contains(string(parts["docProps/app.xml"]), "<Notes>2</Notes>") {
+		t.Error("app.xml 备注数量不正确")
+	}
+}
+
+// TestSpeakerNotesOmitted 测试没有备注时不生成备注母版
+func TestSpeakerNotesOmitted(t *testing.T) {
+	pres := New()
+	pres.AddSlide()
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	if _, ok := parts["ppt/notesMasters/notesMaster1.xml"]; ok {
+		t.Error("没有备注时不应生成备注母版")
+	}
+	if strings.Contains(string(parts["ppt/presentation.xml"]), "notesMasterIdLst") {
+		t.Error("没有备注时不应引用备注母版")
+	}
+}
+
+// TestSpeakerNotesRoundTrip 测试备注写出后重新打开
+func TestSpeakerNotesRoundTrip(t *testing.T) {
+	pres := New()
+	pres.AddSlide().SetNotes("开场白\n介绍议程")
+	pres.AddSlide()
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	opened, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	if opened.GetSlide(0).notes != "开场白\n介绍议程" {
+		t.Errorf("备注读取不正确: %q", opened.GetSlide(0).notes)
+	}
+	if opened.GetSlide(1).notes != "" {
+		t.Errorf("第二张幻灯片不应有备注: %q", opened.GetSlide(1).notes)
+	}
+
+	// 打开的文件已有备注母版，继续添加备注时沿用
+	opened.AddSlide().SetNotes("新增备注")
+	out, err := opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, out)
+	checkWellFormed(t, parts)
+	if _, ok := parts["ppt/theme/theme3.xml"]; ok {
+		t.Error("已有备注母版时不应再生成主题")
+	}
+	presXML := string(parts["ppt/presentation.xml"])
+	if strings.Count(presXML, "<p:notesMasterId ") != 1 {
+		t.Errorf("应该只有一个备注母版: %s", presXML)
+	}
+
+	again, err := OpenReader(bytes.NewReader(out), int64(len(out)))
+	if err != nil {
+		t.Fatalf("再次打开失败: %v", err)
+	}
+	if again.GetSlide(0).notes != "开场白\n介绍议程" || again.GetSlide(2).notes != "新增备注" {
+		t.Errorf("再次打开后备注不正确: %q %q", again.GetSlide(0).notes, again.GetSlide(2).notes)
+	}
+}
+
+// TestSpeakerNotesOnOpenedDeck 测试为没有备注母版的现有文件添加备注
+func TestSpeakerNotesOnOpenedDeck(t *testing.T) {
+	data := buildSampleDeck(t)
+	pres, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	pres.GetSlide(1).SetNotes("图表说明")
+
+	out, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, out)
+	checkWellFormed(t, parts)
+
+	if _, ok := parts["ppt/theme/theme2.xml"]; !ok {
+		t.Error("应该为备注母版生成新主题")
+	}
+	presXML := string(parts["ppt/presentation.xml"])
+	if !strings.Contains(presXML, "</p:sldMasterIdLst><p:notesMasterIdLst>") {
+		t.Errorf("备注母版列表位置不正确: %s", presXML)
+	}
+
+	again, err := OpenReader(bytes.NewReader(out), int64(len(out)))
+	if err != nil {
+		t.Fatalf("再次打开失败: %v", err)
+	}
+	if again.GetSlide(1).notes != "图表说明" {
+		t.Errorf("备注读取不正确: %q", again.GetSlide(1).notes)
+	}
+}
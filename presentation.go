@@ -3,6 +3,7 @@ package genppt
 import (
 	"io"
 	"os"
+	"strings"
 )
 
 // New 创建新的演示文稿
@@ -86,6 +87,88 @@ func (p *Presentation) AddSlide() *Slide {
 	return slide
 }
 
+// nextMediaIndex 返回下一个媒体文件序号（跳过打开文件中已有的序号）
+func (p *Presentation) nextMediaIndex() int {
+	index := len(p.mediaFiles) + 1
+	if p.pkg != nil {
+		index += p.pkg.mediaBase
+	}
+	return index
+}
+
+// mediaRelID 返回媒体文件在幻灯片中的关系ID
+// 按媒体文件序号生成，序号在整个文稿中唯一，不会与其他媒体、图表或幻灯片原有的关系冲突
+func mediaRelID(mediaIndex int) string {
+	return "rIdM" + itoa(mediaIndex)
+}
+
+// mediaTarget 返回媒体文件相对于幻灯片的关系目标
+func (p *Presentation) mediaTarget(rID string) string {
+	for _, m := range p.mediaFiles {
+		if m.rID == rID {
+			return "../" + strings.TrimPrefix(m.path, "ppt/")
+		}
+	}
+	return ""
+}
+
+// slideIndex 返回幻灯片的索引，不存在时返回-1
+func (p *Presentation) slideIndex(slide *Slide) int {
+	for i, s := range p.slides {
+		if s == slide {
+			return i
+		}
+	}
+	return -1
+}
+
+// slideRef 幻灯片在presentation.xml中的引用
+type slideRef struct {
+	id  int    // p:sldId 的 id
+	rID string // presentation.xml.rels 中的关系ID
+}
+
+// slideRefs 计算每张幻灯片的ID和关系ID
+// 打开的文件保留原有幻灯片的ID，新幻灯片使用未占用的ID
+func (p *Presentation) slideRefs() []slideRef {
+	refs := make([]slideRef, len(p.slides))
+	if p.pkg == nil {
+		for i := range p.slides {
+			refs[i] = slideRef{id: 256 + i, rID: "rId" + itoa(2+i)}
+		}
+		return refs
+	}
+
+	nextID := 256
+	nextRel := 1
+	for _, rel := range p.pkg.presRels {
+		nextRel = max(nextRel, atoi(strings.TrimPrefix(rel.id, "rId"))+1)
+	}
+	for _, slide := range p.slides {
+		nextID = max(nextID, slide.sourceID+1)
+		nextRel = max(nextRel, atoi(strings.TrimPrefix(slide.sourceRelID, "rId"))+1)
+	}
+	for i, slide := range p.slides {
+		if slide.sourceID != 0 {
+			refs[i] = slideRef{id: slide.sourceID, rID: slide.sourceRelID}
+			continue
+		}
+		refs[i] = slideRef{id: nextID, rID: "rId" + itoa(nextRel)}
+		nextID++
+		nextRel++
+	}
+	return refs
+}
+
+// ReplaceText 替换所有幻灯片中的文本，返回替换次数
+func (p *Presentation) ReplaceText(old, new string) int {
+	count := 0
+	for _, slide := range p.slides {
+		count += slide.ReplaceText(old, new)
+	}
+	return count
+}
+
 // GetSlide 获取指定索引的幻灯片（从0开始）
 func (p *Presentation) GetSlide(index int) *Slide {
 	if index < 0 || index >= len(p.slides) {
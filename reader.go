@@ -0,0 +1,698 @@
+package genppt
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 关系类型
+const (
+	relTypeOfficeDocument = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	relTypeCoreProps      = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+	relTypeExtendedProps  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
+	relTypeThumbnail      = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail"
+	relTypeSlide          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide"
+	relTypeSlideLayout    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout"
+	relTypeNotesSlide     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide"
+	relTypeNotesMaster    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesMaster"
+	relTypeTableStyles    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/tableStyles"
+)
+
+// sourcePackage 保存从现有PPTX文件读取的原始部件
+type sourcePackage struct {
+	parts         map[string][]byte // 原样保留的部件（路径 -> 内容）
+	partNames     []string          // 保留部件的写入顺序
+	overrides     map[string]string // 部件路径 -> 内容类型
+	defaults      map[string]string // 扩展名 -> 内容类型
+	presentation  []byte            // 原始 ppt/presentation.xml
+	presRels      []packageRel      // presentation.xml.rels 中除幻灯片外的关系
+	rootRels      []packageRel      // _rels/.rels 中除文档属性外的关系
+	layouts       []sourceLayout    // 可用的幻灯片布局
+	mediaBase     int               // 原有媒体文件的最大序号
+	chartBase     int               // 原有图表的最大序号
+	embeddingBase int               // 原有嵌入文件的最大序号
+	themeBase     int               // 原有主题的最大序号
+	notesMaster   string            // 原有备注母版的路径
+}
+
+// packageRel 包内关系
+type packageRel struct {
+	id         string
+	relType    string
+	target     string
+	targetMode string
+	slide      *Slide // 指向其他幻灯片时的目标幻灯片（写入时重新计算路径）
+}
+
+// sourceLayout 现有文件中的幻灯片布局
+type sourceLayout struct {
+	path       string // 部件路径，如 ppt/slideLayouts/slideLayout1.xml
+	name       string // 布局名称（p:cSld 的 name 属性）
+	layoutType string // 布局类型（如 blank、title、obj）
+	phIndexes  []int  // 占位符序号（p:ph 的 idx 属性，未设置时为0）
+}
+
+// xmlRelationships 关系文件结构
+type xmlRelationships struct {
+	Rels []struct {
+		ID         string `xml:"Id,attr"`
+		Type       string `xml:"Type,attr"`
+		Target     string `xml:"Target,attr"`
+		TargetMode string `xml:"TargetMode,attr"`
+	} `xml:"Relationship"`
+}
+
+// xmlContentTypes [Content_Types].xml 结构
+type xmlContentTypes struct {
+	Defaults []struct {
+		Extension   string `xml:"Extension,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Default"`
+	Overrides []struct {
+		PartName    string `xml:"PartName,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Override"`
+}
+
+// xmlPresentation presentation.xml 中读取的部分
+type xmlPresentation struct {
+	SldIDs []xmlSldID `xml:"sldIdLst>sldId"`
+	SldSz  struct {
+		Cx int64 `xml:"cx,attr"`
+		Cy int64 `xml:"cy,attr"`
+	} `xml:"sldSz"`
+}
+
+// xmlSldID p:sldId 元素（id 与 r:id 同名，需按命名空间区分）
+type xmlSldID struct {
+	ID  int
+	RID string
+}
+
+// UnmarshalXML 按命名空间读取 id 和 r:id
+func (s *xmlSldID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "id" {
+			continue
+		}
+		if attr.Name.Space == "" {
+			s.ID = atoi(attr.Value)
+		} else {
+			s.RID = attr.Value
+		}
+	}
+	return d.Skip()
+}
+
+// xmlLayoutInfo 幻灯片布局中读取的部分
+type xmlLayoutInfo struct {
+	Type string `xml:"type,attr"`
+	CSld struct {
+		Name   string `xml:"name,attr"`
+		Shapes []struct {
+			Ph *struct {
+				Idx int `xml:"idx,attr"`
+			} `xml:"nvSpPr>nvPr>ph"`
+		} `xml:"spTree>sp"`
+	} `xml:"cSld"`
+}
+
+// xmlNotesSlide 备注页中读取的部分
+type xmlNotesSlide struct {
+	Shapes []struct {
+		Ph *struct {
+			Type string `xml:"type,attr"`
+		} `xml:"nvSpPr>nvPr>ph"`
+		Paragraphs []struct {
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"txBody>p"`
+	} `xml:"cSld>spTree>sp"`
+}
+
+// xmlCoreProps docProps/core.xml 中读取的部分
+type xmlCoreProps struct {
+	Title   string `xml:"title"`
+	Subject string `xml:"subject"`
+	Creator string `xml:"creator"`
+}
+
+// xmlAppProps docProps/app.xml 中读取的部分
+type xmlAppProps struct {
+	Company string `xml:"Company"`
+}
+
+var (
+	relAttrRe      = regexp.MustCompile(`(\sr:[A-Za-z]+=")([^"]*)(")`)
+	shapeIDRe      = regexp.MustCompile(`<p:cNvPr\b[^>]*?\sid="(\d+)"`)
+	textRunRe      = regexp.MustCompile(`(<a:t(?:\s[^>]*)?>)([^<]*)(</a:t>)`)
+	sldIDLstRe     = regexp.MustCompile(`(?s)<p:sldIdLst\s*/>|<p:sldIdLst>.*?</p:sldIdLst>`)
+	sldSzRe        = regexp.MustCompile(`<p:sldSz\b[^>]*/>`)
+	sectionRe      = regexp.MustCompile(`(?s)<p14:section\b[^>]*?(?:/>|>.*?</p14:section>)`)
+	sectionExtRe   = regexp.MustCompile(`(?s)<p:ext uri="\{521415D9-36F7-43E2-AB2F-B90AF26B5E84\}">.*?</p:ext>`)
+	custShowRe     = regexp.MustCompile(`(?s)<p:custShow\b[^>]*?(?:/>|>.*?</p:custShow>)`)
+	custShowLstRe  = regexp.MustCompile(`(?s)<p:custShowLst\s*/>|<p:custShowLst>.*?</p:custShowLst>`)
+	sectionSldIDRe = regexp.MustCompile(`<p14:sldId\s+id="(\d+)"\s*/>`)
+	custSldIDRe    = regexp.MustCompile(`<p:sldId\s+r:id="([^"]*)"\s*/>`)
+	trailingNum    = regexp.MustCompile(`(\d+)\.[^./]+$`)
+)
+
+// Open 打开现有的PPTX文件
+func Open(filename string) (*Presentation, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return OpenReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// OpenReader 从io.ReaderAt读取PPTX文件
+// 能识别的内容（幻灯片、备注、文档属性）读入模型，其余部件在写出时原样保留
+func OpenReader(r io.ReaderAt, size int64) (*Presentation, error) {
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	names := make([]string, 0, len(zipReader.File))
+	for _, f := range zipReader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[f.Name] = data
+		names = append(names, f.Name)
+	}
+
+	return parsePackage(files, names)
+}
+
+// parsePackage 将PPTX包内容解析为演示文稿
+func parsePackage(files map[string][]byte, names []string) (*Presentation, error) {
+	presXML, ok := files["ppt/presentation.xml"]
+	if !ok {
+		return nil, fmt.Errorf("无效的PPTX文件: 缺少 ppt/presentation.xml")
+	}
+
+	p := New()
+	pkg := &sourcePackage{
+		parts:        make(map[string][]byte),
+		overrides:    make(map[string]string),
+		defaults:     make(map[string]string),
+		presentation: presXML,
+	}
+	p.pkg = pkg
+
+	// 内容类型
+	var types xmlContentTypes
+	if err := xml.Unmarshal(files["[Content_Types].xml"], &types); err != nil {
+		return nil, fmt.Errorf("解析 [Content_Types].xml 失败: %w", err)
+	}
+	for _, d := range types.Defaults {
+		pkg.defaults[strings.ToLower(d.Extension)] = d.ContentType
+	}
+	for _, o := range types.Overrides {
+		pkg.overrides[strings.TrimPrefix(o.PartName, "/")] = o.ContentType
+	}
+
+	// 重新生成的部件不再保留
+	skip := map[string]bool{
+		"[Content_Types].xml":             true,
+		"_rels/.rels":                     true,
+		"docProps/core.xml":               true,
+		"docProps/app.xml":                true,
+		"ppt/presentation.xml":            true,
+		"ppt/_rels/presentation.xml.rels": true,
+	}
+
+	// 根关系
+	rootRels, err := parseRels(files["_rels/.rels"])
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range rootRels {
+		switch rel.relType {
+		case relTypeOfficeDocument, relTypeCoreProps, relTypeExtendedProps:
+		case relTypeThumbnail:
+			skip[resolvePartPath("", rel.target)] = true
+		default:
+			pkg.rootRels = append(pkg.rootRels, rel)
+		}
+	}
+
+	// 文档属性
+	var core xmlCoreProps
+	if xml.Unmarshal(files["docProps/core.xml"], &core) == nil {
+		p.title = core.Title
+		p.subject = core.Subject
+		p.author = core.Creator
+	}
+	var app xmlAppProps
+	if xml.Unmarshal(files["docProps/app.xml"], &app) == nil {
+		p.company = app.Company
+	}
+
+	// 演示文稿关系
+	presRels, err := parseRels(files["ppt/_rels/presentation.xml.rels"])
+	if err != nil {
+		return nil, err
+	}
+	slideParts := make(map[string]string)
+	for _, rel := range presRels {
+		if rel.relType == relTypeSlide {
+			slideParts[rel.id] = resolvePartPath("ppt", rel.target)
+		} else {
+			pkg.presRels = append(pkg.presRels, rel)
+		}
+	}
+
+	var pres xmlPresentation
+	if err := xml.Unmarshal(presXML, &pres); err != nil {
+		return nil, fmt.Errorf("解析 presentation.xml 失败: %w", err)
+	}
+	if pres.SldSz.Cx > 0 && pres.SldSz.Cy > 0 {
+		p.slideWidth = pres.SldSz.Cx
+		p.slideHeight = pres.SldSz.Cy
+	}
+
+	// 幻灯片
+	slidesByPart := make(map[string]*Slide)
+	for _, sldID := range pres.SldIDs {
+		partName, ok := slideParts[sldID.RID]
+		if !ok {
+			return nil, fmt.Errorf("无效的PPTX文件: 找不到幻灯片关系 %s", sldID.RID)
+		}
+		data, ok := files[partName]
+		if !ok {
+			return nil, fmt.Errorf("无效的PPTX文件: 缺少 %s", partName)
+		}
+		slide := p.AddSlide()
+		slide.sourceID = sldID.ID
+		slide.sourceRelID = sldID.RID
+		if err := slide.parseSource(files, partName, data); err != nil {
+			return nil, fmt.Errorf("解析 %s 失败: %w", partName, err)
+		}
+		slidesByPart[partName] = slide
+	}
+
+	// 幻灯片之间的链接改为指向幻灯片对象
+	for _, slide := range p.slides {
+		for i := range slide.sourceRels {
+			rel := &slide.sourceRels[i]
+			if rel.relType == relTypeSlide {
+				rel.slide = slidesByPart[resolvePartPath("ppt/slides", rel.target)]
+			}
+		}
+	}
+
+	// 其余部件原样保留（幻灯片和备注页由模型重新生成）
+	for _, name := range names {
+		if skip[name] || strings.HasPrefix(name, "ppt/slides/") || strings.HasPrefix(name, "ppt/notesSlides/") {
+			continue
+		}
+		pkg.parts[name] = files[name]
+		pkg.partNames = append(pkg.partNames, name)
+	}
+	pkg.scanParts()
+
+	if len(pkg.layouts) == 0 {
+		return nil, fmt.Errorf("无效的PPTX文件: 缺少幻灯片布局")
+	}
+
+	return p, nil
+}
+
+// parseSource 从原始幻灯片XML读取内容
+func (s *Slide) parseSource(files map[string][]byte, partName string, data []byte) error {
+	rels, err := parseRels(files[relsPathFor(partName)])
+	if err != nil {
+		return err
+	}
+
+	// 关系ID重映射，避免与生成的关系冲突
+	remap := make(map[string]string)
+	for _, rel := range rels {
+		switch rel.relType {
+		case relTypeSlideLayout:
+			s.layoutTarget = rel.target
+		case relTypeNotesSlide:
+			s.notes = parseNotesText(files[resolvePartPath(path.Dir(partName), rel.target)])
+		default:
+			newID := "rIdP" + itoa(len(s.sourceRels)+1)
+			remap[rel.id] = newID
+			rel.id = newID
+			s.sourceRels = append(s.sourceRels, rel)
+		}
+	}
+	data = relAttrRe.ReplaceAllFunc(data, func(m []byte) []byte {
+		parts := relAttrRe.FindSubmatch(m)
+		if newID, ok := remap[string(parts[2])]; ok {
+			return []byte(string(parts[1]) + newID + string(parts[3]))
+		}
+		return m
+	})
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []string
+	for {
+		start := decoder.InputOffset()
+		tok, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			parent := ""
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+
+			switch {
+			case len(stack) == 0:
+				s.rawAttrs = extraRootAttrs(t.Attr)
+			case len(stack) == 1 && name != "cSld" && name != "clrMapOvr":
+				raw, err := captureElement(decoder, data, start)
+				if err != nil {
+					return err
+				}
+				switch name {
+				case "transition", "AlternateContent":
+					s.rawTransition += raw
+				case "timing":
+					s.rawTiming = raw
+				case "extLst":
+					s.rawExtLst = raw
+				}
+				continue
+			case parent == "cSld" && name == "bg":
+				raw, err := captureElement(decoder, data, start)
+				if err != nil {
+					return err
+				}
+				s.rawBackground = raw
+				continue
+			case parent == "spTree" && len(stack) == 3 && name != "nvGrpSpPr" && name != "grpSpPr":
+				raw, err := captureElement(decoder, data, start)
+				if err != nil {
+					return err
+				}
+				s.objects = append(s.objects, &rawObject{xml: raw})
+				for _, m := range shapeIDRe.FindAllStringSubmatch(raw, -1) {
+					if id := atoi(m[1]); id > s.maxShapeID {
+						s.maxShapeID = id
+					}
+				}
+				continue
+			}
+			stack = append(stack, name)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return nil
+}
+
+// captureElement 读取到当前元素结束，返回元素的原始XML
+func captureElement(decoder *xml.Decoder, data []byte, start int64) (string, error) {
+	depth := 1
+	for depth > 0 {
+		tok, err := decoder.RawToken()
+		if err != nil {
+			return "", err
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return string(data[start:decoder.InputOffset()]), nil
+}
+
+// extraRootAttrs 返回根元素上除 a/r/p 命名空间外的属性
+func extraRootAttrs(attrs []xml.Attr) string {
+	var sb strings.Builder
+	for _, attr := range attrs {
+		name := attr.Name.Local
+		if attr.Name.Space != "" {
+			name = attr.Name.Space + ":" + name
+		}
+		if name == "xmlns:a" || name == "xmlns:r" || name == "xmlns:p" {
+			continue
+		}
+		sb.WriteString(` `)
+		sb.WriteString(name)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeXML(attr.Value))
+		sb.WriteString(`"`)
+	}
+	return sb.String()
+}
+
+// parseNotesText 读取备注页正文占位符中的文本
+func parseNotesText(data []byte) string {
+	var notes xmlNotesSlide
+	if len(data) == 0 || xml.Unmarshal(data, &notes) != nil {
+		return ""
+	}
+	for _, sp := range notes.Shapes {
+		if sp.Ph == nil || sp.Ph.Type != "body" {
+			continue
+		}
+		lines := make([]string, 0, len(sp.Paragraphs))
+		for _, para := range sp.Paragraphs {
+			var line strings.Builder
+			for _, r := range para.Runs {
+				line.WriteString(r.Text)
+			}
+			lines = append(lines, line.String())
+		}
+		return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	}
+	return ""
+}
+
+// parseRels 解析关系文件，文件不存在时返回空
+func parseRels(data []byte) ([]packageRel, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var doc xmlRelationships
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("解析关系文件失败: %w", err)
+	}
+	rels := make([]packageRel, 0, len(doc.Rels))
+	for _, r := range doc.Rels {
+		rels = append(rels, packageRel{
+			id:         r.ID,
+			relType:    r.Type,
+			target:     r.Target,
+			targetMode: r.TargetMode,
+		})
+	}
+	return rels, nil
+}
+
+// relsPathFor 返回部件对应的关系文件路径
+func relsPathFor(partName string) string {
+	return path.Dir(partName) + "/_rels/" + path.Base(partName) + ".rels"
+}
+
+// resolvePartPath 将关系目标解析为包内路径
+func resolvePartPath(baseDir, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return target[1:]
+	}
+	return path.Clean(path.Join(baseDir, target))
+}
+
+// scanParts 扫描保留的部件，记录布局和已占用的序号
+func (pkg *sourcePackage) scanParts() {
+	pkg.layouts = pkg.layouts[:0]
+	pkg.mediaBase = 0
+	pkg.chartBase = 0
+	pkg.embeddingBase = 0
+	pkg.themeBase = 0
+	pkg.notesMaster = ""
+	for _, rel := range pkg.presRels {
+		if rel.relType == relTypeNotesMaster {
+			if name := resolvePartPath("ppt", rel.target); pkg.parts[name] != nil {
+				pkg.notesMaster = name
+			}
+		}
+	}
+	for _, name := range pkg.partNames {
+		switch {
+		case strings.HasPrefix(name, "ppt/slideLayouts/") && strings.HasSuffix(name, ".xml") && !strings.Contains(name, "/_rels/"):
+			var info xmlLayoutInfo
+			xml.Unmarshal(pkg.parts[name], &info)
+			layout := sourceLayout{
+				path:       name,
+				name:       info.CSld.Name,
+				layoutType: info.Type,
+			}
+			for _, sp := range info.CSld.Shapes {
+				if sp.Ph != nil {
+					layout.phIndexes = append(layout.phIndexes, sp.Ph.Idx)
+				}
+			}
+			pkg.layouts = append(pkg.layouts, layout)
+		case strings.HasPrefix(name, "ppt/media/"):
+			pkg.mediaBase = max(pkg.mediaBase, partNumber(name))
+		case strings.HasPrefix(name, "ppt/charts/") && strings.HasSuffix(name, ".xml") && !strings.Contains(name, "/_rels/"):
+			pkg.chartBase = max(pkg.chartBase, partNumber(name))
+		case strings.HasPrefix(name, "ppt/embeddings/"):
+			pkg.embeddingBase = max(pkg.embeddingBase, partNumber(name))
+		case strings.HasPrefix(name, "ppt/theme/") && strings.HasSuffix(name, ".xml") && !strings.Contains(name, "/_rels/"):
+			pkg.themeBase = max(pkg.themeBase, partNumber(name))
+		}
+	}
+	sort.SliceStable(pkg.layouts, func(i, j int) bool {
+		return partNumber(pkg.layouts[i].path) < partNumber(pkg.layouts[j].path)
+	})
+}
+
+// defaultLayoutTarget 返回新幻灯片使用的布局（优先空白布局）
+func (pkg *sourcePackage) defaultLayoutTarget() string {
+	if layout := pkg.findLayoutByType("blank"); layout != nil {
+		return layout.relTarget()
+	}
+	return pkg.layouts[0].relTarget()
+}
+
+// findLayoutByType 按布局类型查找布局
+func (pkg *sourcePackage) findLayoutByType(layoutType string) *sourceLayout {
+	for i := range pkg.layouts {
+		if pkg.layouts[i].layoutType == layoutType {
+			return &pkg.layouts[i]
+		}
+	}
+	return nil
+}
+
+// findLayoutByName 按名称查找布局（不区分大小写）
+func (pkg *sourcePackage) findLayoutByName(name string) *sourceLayout {
+	for i := range pkg.layouts {
+		if strings.EqualFold(pkg.layouts[i].name, name) {
+			return &pkg.layouts[i]
+		}
+	}
+	return nil
+}
+
+// relTarget 返回布局相对于幻灯片的关系目标
+func (l *sourceLayout) relTarget() string {
+	return "../" + strings.TrimPrefix(l.path, "ppt/")
+}
+
+// prune 删除不再被任何关系引用的部件（如模板示例幻灯片使用的图片）
+func (pkg *sourcePackage) prune(slides []*Slide) {
+	reachable := make(map[string]bool)
+	var queue []string
+	visit := func(baseDir string, rels []packageRel) {
+		for _, rel := range rels {
+			if rel.targetMode == "External" {
+				continue
+			}
+			name := resolvePartPath(baseDir, rel.target)
+			if !reachable[name] {
+				reachable[name] = true
+				queue = append(queue, name)
+			}
+		}
+	}
+
+	visit("", pkg.rootRels)
+	visit("ppt", pkg.presRels)
+	for _, slide := range slides {
+		visit("ppt/slides", slide.sourceRels)
+		if slide.layoutTarget != "" {
+			visit("ppt/slides", []packageRel{{target: slide.layoutTarget}})
+		}
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		relsName := relsPathFor(name)
+		if data, ok := pkg.parts[relsName]; ok {
+			reachable[relsName] = true
+			rels, _ := parseRels(data)
+			visit(path.Dir(name), rels)
+		}
+	}
+
+	kept := pkg.partNames[:0]
+	for _, name := range pkg.partNames {
+		if reachable[name] {
+			kept = append(kept, name)
+		} else {
+			delete(pkg.parts, name)
+		}
+	}
+	pkg.partNames = kept
+	pkg.scanParts()
+}
+
+// partNumber 返回部件文件名末尾的序号，如 image12.png -> 12
+func partNumber(name string) int {
+	m := trailingNum.FindStringSubmatch(name)
+	if m == nil {
+		return 0
+	}
+	return atoi(m[1])
+}
+
+// atoi 简单的字符串转整数，非数字返回0
+func atoi(s string) int {
+	n := 0
+	for _, ch := range s {
+		if ch < '0' || ch > '9' {
+			return 0
+		}
+		n = n*10 + int(ch-'0')
+	}
+	return n
+}
+
+// replaceTextRuns 替换XML中 a:t 元素内的文本，返回替换次数
+func replaceTextRuns(xmlStr, old, new string) (string, int) {
+	count := 0
+	result := textRunRe.ReplaceAllStringFunc(xmlStr, func(m string) string {
+		parts := textRunRe.FindStringSubmatch(m)
+		text := html.UnescapeString(parts[2])
+		n := strings.Count(text, old)
+		if n == 0 {
+			return m
+		}
+		count += n
+		return parts[1] + escapeXML(strings.ReplaceAll(text, old, new)) + parts[3]
+	})
+	return result, count
+}
@@ -0,0 +1,281 @@
+package genppt
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// readZipParts 读取ZIP中的所有部件
+func readZipParts(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("生成的数据不是有效的ZIP文件: %v", err)
+	}
+	parts := make(map[string][]byte)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("打开 %s 失败: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = content
+	}
+	return parts
+}
+
+// checkWellFormed 检查所有XML部件格式正确
+func checkWellFormed(t *testing.T, parts map[string][]byte) {
+	t.Helper()
+	for name, content := range parts {
+		if !strings.HasSuffix(name, ".xml") && !strings.HasSuffix(name, ".rels") {
+			continue
+		}
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Errorf("%s 不是有效的XML: %v", name, err)
+				break
+			}
+		}
+	}
+}
+
+// buildSampleDeck 生成用于打开测试的演示文稿
+func buildSampleDeck(t *testing.T) []byte {
+	t.Helper()
+	pres := New()
+	pres.SetTitle("原始标题").SetAuthor("作者").SetCompany("公司")
+	pres.SetSlideSize4x3()
+
+	slide1 := pres.AddSlide()
+	slide1.AddText("季度报告 Q1", TextOptions{X: 1, Y: 1, Width: 8, Height: 1})
+	slide1.AddImage(ImageOptions{Data: []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}, X: 1, Y: 2})
+
+	slide2 := pres.AddSlide()
+	slide2.AddBarChart("销售", []string{"A", "B"}, map[string][]float64{"S1": {1, 2}}, DefaultChartOptions())
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	return data
+}
+
+// TestOpenReader 测试打开现有文件
+func TestOpenReader(t *testing.T) {
+	data := buildSampleDeck(t)
+
+	pres, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	if pres.SlideCount() != 2 {
+		t.Fatalf("应该有 2 张幻灯片，实际有 %d 张", pres.SlideCount())
+	}
+	if pres.title != "原始标题" || pres.author != "作者" || pres.company != "公司" {
+		t.Errorf("文档属性读取失败: %q %q %q", pres.title, pres.author, pres.company)
+	}
+	if pres.slideWidth != 9144000 || pres.slideHeight != 6858000 {
+		t.Errorf("幻灯片尺寸读取失败: %d x %d", pres.slideWidth, pres.slideHeight)
+	}
+	if len(pres.GetSlide(0).objects) != 2 {
+		t.Errorf("第一张幻灯片应该有 2 个对象，实际有 %d 个", len(pres.GetSlide(0).objects))
+	}
+}
+
+// TestOpenRoundTrip 测试打开后追加幻灯片并重新写出
+func TestOpenRoundTrip(t *testing.T) {
+	data := buildSampleDeck(t)
+	pres, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+
+	slide := pres.AddSlide()
+	slide.AddText("新增内容", TextOptions{X: 1, Y: 1})
+	slide.AddImage(ImageOptions{Data: []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 0, 0, 0}, X: 1, Y: 2})
+	slide.AddPieChart("占比", []string{"A", "B"}, []float64{1, 1}, DefaultChartOptions())
+
+	out, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, out)
+	checkWellFormed(t, parts)
+
+	for _, name := range []string{
+		"ppt/media/image1.png",
+		"ppt/media/image2.jpeg",
+		"ppt/charts/chart1.xml",
+		"ppt/charts/chart2.xml",
+		"ppt/slides/slide3.xml",
+		"ppt/theme/theme1.xml",
+		"ppt/slideLayouts/slideLayout1.xml",
+	} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("缺少部件: %s", name)
+		}
+	}
+
+	slide1 := string(parts["ppt/slides/slide1.xml"])
+	if !strings.Contains(slide1, "季度报告 Q1") {
+		t.Error("原有幻灯片的文本丢失")
+	}
+	rels1 := string(parts["ppt/slides/_rels/slide1.xml.rels"])
+	if !strings.Contains(rels1, `Target="../media/image1.png"`) {
+		t.Errorf("原有图片关系丢失: %s", rels1)
+	}
+	rels3 := string(parts["ppt/slides/_rels/slide3.xml.rels"])
+	if !strings.Contains(rels3, `Target="../media/image2.jpeg"`) || !strings.Contains(rels3, `Target="../charts/chart2.xml"`) {
+		t.Errorf("新增对象的关系错误: %s", rels3)
+	}
+
+	presXML := string(parts["ppt/presentation.xml"])
+	if strings.Count(presXML, "<p:sldId ") != 3 {
+		t.Errorf("presentation.xml 应该引用 3 张幻灯片: %s", presXML)
+	}
+
+	// 再次打开
+	again, err := OpenReader(bytes.NewReader(out), int64(len(out)))
+	if err != nil {
+		t.Fatalf("再次打开失败: %v", err)
+	}
+	if again.SlideCount() != 3 {
+		t.Errorf("再次打开后应该有 3 张幻灯片，实际有 %d 张", again.SlideCount())
+	}
+}
+
+// TestOpenManyMedia 测试打开有大量媒体文件的文稿后，新增对象的关系ID不冲突
+func TestOpenManyMedia(t *testing.T) {
+	png := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	pres := New()
+	slide := pres.AddSlide()
+	for range 100 {
+		slide.AddImage(ImageOptions{Data: png})
+	}
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+
+	opened, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	added := opened.AddSlide()
+	added.AddImage(ImageOptions{Data: png})
+	added.AddBarChart("销售", []string{"A", "B"}, map[string][]float64{"S1": {1, 2}}, DefaultChartOptions())
+	added.AddVideo(VideoOptions{Data: []byte{0, 0, 0, 0x18, 'f', 't', 'y', 'p'}, Poster: png})
+	added.AddAudio(AudioOptions{Data: []byte{'I', 'D', '3', 0}})
+
+	out, err := opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, out)
+	checkWellFormed(t, parts)
+
+	rels := string(parts["ppt/slides/_rels/slide2.xml.rels"])
+	seen := make(map[string]bool)
+	for _, m := range regexp.MustCompile(`Id="([^"]*)"`).FindAllStringSubmatch(rels, -1) {
+		if seen[m[1]] {
+			t.Errorf("关系ID %s 重复: %s", m[1], rels)
+		}
+		seen[m[1]] = true
+	}
+	for _, target := range []string{"../media/image101.png", "../charts/chart1.xml", "../media/video102.mp4", "../media/poster103.png", "../media/audio104.mp3"} {
+		if !strings.Contains(rels, `Target="`+target+`"`) {
+			t.Errorf("slide2.xml.rels 应该引用 %s: %s", target, rels)
+		}
+	}
+	slideXML := string(parts["ppt/slides/slide2.xml"])
+	for id := range seen {
+		if id != "rId1" && !strings.Contains(slideXML, `"`+id+`"`) {
+			t.Errorf("slide2.xml 应该引用关系 %s", id)
+		}
+	}
+}
+
+// TestOpenPreservesUnknownParts 测试未识别的部件原样保留
+func TestOpenPreservesUnknownParts(t *testing.T) {
+	data := buildSampleDeck(t)
+	parts := readZipParts(t, data)
+
+	custom := []byte(`<?xml version="1.0" encoding="UTF-8"?><root xmlns="urn:test">保留我</root>`)
+	parts["customXml/item1.xml"] = custom
+	parts["[Content_Types].xml"] = []byte(strings.Replace(string(parts["[Content_Types].xml"]), `</Types>`,
+		`<Default Extension="bin" ContentType="application/octet-stream"/><Override PartName="/customXml/item1.xml" ContentType="application/vnd.test+xml"/></Types>`, 1))
+	parts["ppt/embeddings/blob1.bin"] = []byte{1, 2, 3}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, _ := zipWriter.Create(name)
+		w.Write(content)
+	}
+	zipWriter.Close()
+
+	pres, err := OpenReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	out, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	written := readZipParts(t, out)
+
+	if !bytes.Equal(written["customXml/item1.xml"], custom) {
+		t.Error("未识别的部件没有原样保留")
+	}
+	if !bytes.Equal(written["ppt/embeddings/blob1.bin"], []byte{1, 2, 3}) {
+		t.Error("二进制部件没有原样保留")
+	}
+	types := string(written["[Content_Types].xml"])
+	if !strings.Contains(types, `PartName="/customXml/item1.xml" ContentType="application/vnd.test+xml"`) {
+		t.Error("保留部件的内容类型丢失")
+	}
+	if !strings.Contains(types, `Extension="bin"`) {
+		t.Error("保留部件的默认内容类型丢失")
+	}
+}
+
+// TestReplaceText 测试替换文本
+func TestReplaceText(t *testing.T) {
+	data := buildSampleDeck(t)
+	pres, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+
+	if n := pres.ReplaceText("Q1", "Q2 & Q3"); n != 1 {
+		t.Errorf("应该替换 1 处，实际替换 %d 处", n)
+	}
+	slide := pres.GetSlide(0)
+	xmlStr := slide.generateSlide(slide.collectLinks())
+	if !strings.Contains(xmlStr, "季度报告 Q2 &amp; Q3") {
+		t.Error("替换后的文本不正确")
+	}
+}
+
+// TestOpenInvalid 测试打开无效文件
+func TestOpenInvalid(t *testing.T) {
+	data := []byte("not a zip file")
+	if _, err := OpenReader(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Error("打开无效文件应该返回错误")
+	}
+	if _, err := Open("not_exist.pptx"); err == nil {
+		t.Error("打开不存在的文件应该返回错误")
+	}
+}
@@ -1,14 +1,45 @@
 package genppt
 
 import (
+	"math"
 	"os"
+	"strconv"
+	"strings"
 )
 
-// AddText 添加文本框
+// AddText 添加文本框，文本中的换行拆分为段落
 func (s *Slide) AddText(text string, opts TextOptions) *Slide {
+	s.objects = append(s.objects, newTextObject(text, nil, opts))
+	return s
+}
+
+// AddRichText 添加富文本框，每个段落和文本运行可以单独设置格式
+// opts 提供位置和默认格式
+func (s *Slide) AddRichText(paragraphs []Paragraph, opts TextOptions) *Slide {
+	// 复制段落，避免替换文本时修改调用方的数据
+	s.objects = append(s.objects, newTextObject("", copyParagraphs(paragraphs), opts))
+	return s
+}
+
+// copyParagraphs 复制段落和其中的文本运行
+func copyParagraphs(paragraphs []Paragraph) []Paragraph {
+	if paragraphs == nil {
+		return nil
+	}
+	copied := make([]Paragraph, len(paragraphs))
+	for i, para := range paragraphs {
+		para.Runs = append([]TextRun(nil), para.Runs...)
+		copied[i] = para
+	}
+	return copied
+}
+
+// newTextObject 创建文本对象并设置默认值
+func newTextObject(text string, paragraphs []Paragraph, opts TextOptions) *textObject {
 	obj := &textObject{
-		text:    text,
-		options: opts,
+		text:       text,
+		paragraphs: paragraphs,
+		options:    opts,
 	}
 	// 设置默认值
 	if obj.options.FontFace == "" {
@@ -26,8 +57,7 @@ func (s *Slide) AddText(text string, opts TextOptions) *Slide {
 	if obj.options.VAlign == "" {
 		obj.options.VAlign = VAlignTop
 	}
-	s.objects = append(s.objects, obj)
-	return s
+	return obj
 }
 
 // AddShape 添加形状
@@ -38,13 +68,13 @@ func (s *Slide) AddShape(shapeType ShapeType, opts ShapeOptions) *Slide {
 	}
 	// 设置默认值
 	if obj.options.Fill == "" {
-		obj.options.Fill = "4472C4" // Office默认蓝色
+		obj.options.Fill = "accent1" // 主题强调色1，随主题改变
 	}
 	if obj.options.LineWidth == 0 {
 		obj.options.LineWidth = 1.0
 	}
 	if obj.options.LineColor == "" {
-		obj.options.LineColor = "2F5496"
+		obj.options.LineColor = "accent1-75"
 	}
 	s.objects = append(s.objects, obj)
 	return s
@@ -59,13 +89,13 @@ func (s *Slide) AddShapeWithText(shapeType ShapeType, text string, opts ShapeOpt
 	}
 	// 设置默认值
 	if obj.options.Fill == "" {
-		obj.options.Fill = "4472C4"
+		obj.options.Fill = "accent1"
 	}
 	if obj.options.LineWidth == 0 {
 		obj.options.LineWidth = 1.0
 	}
 	if obj.options.LineColor == "" {
-		obj.options.LineColor = "2F5496"
+		obj.options.LineColor = "accent1-75"
 	}
 	s.objects = append(s.objects, obj)
 	return s
@@ -73,9 +103,18 @@ func (s *Slide) AddShapeWithText(shapeType ShapeType, text string, opts ShapeOpt
 
 // AddTable 添加表格
 func (s *Slide) AddTable(rows [][]TableCell, opts TableOptions) *Slide {
+	// 复制单元格，避免替换文本时修改调用方的数据
+	copied := make([][]TableCell, len(rows))
+	for i, row := range rows {
+		copied[i] = make([]TableCell, len(row))
+		for j, cell := range row {
+			cell.Paragraphs = copyParagraphs(cell.Paragraphs)
+			copied[i][j] = cell
+		}
+	}
 	obj := &tableObject{
-		rows:    rows,
-		options: opts,
+		rows:    copied,
+		options: opts.withAutoColWidths(rows),
 	}
 	// 设置默认值
 	if obj.options.FontFace == "" {
@@ -84,17 +123,20 @@ func (s *Slide) AddTable(rows [][]TableCell, opts TableOptions) *Slide {
 	if obj.options.FontSize == 0 {
 		obj.options.FontSize = 14
 	}
-	if obj.options.FontColor == "" {
-		obj.options.FontColor = getDefaultColor()
-	}
-	if obj.options.Border.Width == 0 {
-		obj.options.Border.Width = 1.0
-	}
-	if obj.options.Border.Color == "" {
-		obj.options.Border.Color = "CCCCCC"
-	}
-	if obj.options.Border.Style == "" {
-		obj.options.Border.Style = BorderSolid
+	// 使用表格样式时文字颜色和边框由样式决定
+	if obj.options.Style == "" {
+		if obj.options.FontColor == "" {
+			obj.options.FontColor = getDefaultColor()
+		}
+		if obj.options.Border.Width == 0 {
+			obj.options.Border.Width = 1.0
+		}
+		if obj.options.Border.Color == "" {
+			obj.options.Border.Color = "CCCCCC"
+		}
+		if obj.options.Border.Style == "" {
+			obj.options.Border.Style = BorderSolid
+		}
 	}
 	s.objects = append(s.objects, obj)
 	return s
@@ -126,8 +168,8 @@ func (s *Slide) AddImage(opts ImageOptions) *Slide {
 	}
 
 	// 生成媒体文件关系ID
-	mediaIndex := len(s.presentation.mediaFiles) + 1
-	rID := "rId" + itoa(mediaIndex+100) // 使用较大的rId避免冲突
+	mediaIndex := s.presentation.nextMediaIndex()
+	rID := mediaRelID(mediaIndex)
 	mediaPath := "ppt/media/image" + itoa(mediaIndex) + "." + ext
 
 	// 添加到演示文稿的媒体文件列表
@@ -159,43 +201,148 @@ func (s *Slide) SetNotes(notes string) *Slide {
 	return s
 }
 
+// ReplaceText 替换幻灯片中的文本，返回替换次数
+// 只匹配同一文本运行内的内容，被拆分到多个格式运行中的文本不会被替换
+func (s *Slide) ReplaceText(old, new string) int {
+	if old == "" {
+		return 0
+	}
+	count := 0
+	for _, obj := range s.objects {
+		switch o := obj.(type) {
+		case *textObject:
+			count += strings.Count(o.text, old)
+			o.text = strings.ReplaceAll(o.text, old, new)
+			for i := range o.paragraphs {
+				runs := o.paragraphs[i].Runs
+				for j := range runs {
+					count += strings.Count(runs[j].Text, old)
+					runs[j].Text = strings.ReplaceAll(runs[j].Text, old, new)
+				}
+			}
+		case *shapeObject:
+			count += strings.Count(o.text, old)
+			o.text = strings.ReplaceAll(o.text, old, new)
+		case *placeholderObject:
+			count += strings.Count(o.text, old)
+			o.text = strings.ReplaceAll(o.text, old, new)
+		case *tableObject:
+			for _, row := range o.rows {
+				for i := range row {
+					count += strings.Count(row[i].Text, old)
+					row[i].Text = strings.ReplaceAll(row[i].Text, old, new)
+					for p := range row[i].Paragraphs {
+						runs := row[i].Paragraphs[p].Runs
+						for r := range runs {
+							count += strings.Count(runs[r].Text, old)
+							runs[r].Text = strings.ReplaceAll(runs[r].Text, old, new)
+						}
+					}
+				}
+			}
+		case *rawObject:
+			var n int
+			o.xml, n = replaceTextRuns(o.xml, old, new)
+			count += n
+		}
+	}
+	return count
+}
+
 // SetLayout 设置幻灯片布局
 func (s *Slide) SetLayout(layout SlideLayout) *Slide {
 	s.layout = layout
+	s.layoutTarget = ""
 	return s
 }
 
-// itoa 简单的整数转字符串（避免导入strconv）
-func itoa(n int) string {
-	if n == 0 {
-		return "0"
-	}
-	if n < 0 {
-		return "-" + itoa(-n)
+// SetTitle 设置标题占位符的文本
+func (s *Slide) SetTitle(title string) *Slide {
+	return s.setPlaceholder(0, title)
+}
+
+// SetBody 设置正文占位符的文本（标题布局中为副标题），多行文本按换行拆分为段落
+func (s *Slide) SetBody(body string) *Slide {
+	return s.setPlaceholder(1, body)
+}
+
+// SetColumn 设置两栏布局中第 index 栏（从0开始）的文本
+func (s *Slide) SetColumn(index int, text string) *Slide {
+	if index < 0 || index > 1 {
+		return s
 	}
-	var digits []byte
-	for n > 0 {
-		digits = append([]byte{byte('0' + n%10)}, digits...)
-		n /= 10
+	return s.setPlaceholder(index+1, text)
+}
+
+// SetTitleAnimation 设置标题占位符的动画
+func (s *Slide) SetTitleAnimation(anim AnimationOptions) *Slide {
+	s.placeholder(0).animation = &anim
+	return s
+}
+
+// SetBodyAnimation 设置正文占位符的动画，ByParagraph 为 true 时逐段播放
+func (s *Slide) SetBodyAnimation(anim AnimationOptions) *Slide {
+	s.placeholder(1).animation = &anim
+	return s
+}
+
+// SetColumnAnimation 设置两栏布局中第 index 栏（从0开始）的动画
+func (s *Slide) SetColumnAnimation(index int, anim AnimationOptions) *Slide {
+	if index < 0 || index > 1 {
+		return s
 	}
-	return string(digits)
+	s.placeholder(index + 1).animation = &anim
+	return s
 }
 
-// ftoa 简单的浮点数转字符串
-func ftoa(f float64) string {
-	if f == float64(int64(f)) {
-		return itoa(int(f))
+// setPlaceholder 设置占位符文本
+func (s *Slide) setPlaceholder(idx int, text string) *Slide {
+	s.placeholder(idx).text = text
+	return s
+}
+
+// placeholder 返回指定序号的占位符，不存在时添加，占位符按序号排在其他对象之前
+func (s *Slide) placeholder(idx int) *placeholderObject {
+	insertAt := 0
+	for i, obj := range s.objects {
+		if ph, ok := obj.(*placeholderObject); ok {
+			if ph.idx == idx {
+				return ph
+			}
+			if ph.idx < idx {
+				insertAt = i + 1
+			}
+		}
 	}
-	// 保留小数点后两位
-	intPart := int64(f)
-	fracPart := int64((f - float64(intPart)) * 100)
-	if fracPart < 0 {
-		fracPart = -fracPart
+	ph := &placeholderObject{idx: idx}
+	s.objects = append(s.objects, nil)
+	copy(s.objects[insertAt+1:], s.objects[insertAt:])
+	s.objects[insertAt] = ph
+	return ph
+}
+
+// layoutRelTarget 返回幻灯片布局关系的目标路径
+func (s *Slide) layoutRelTarget() string {
+	if s.layoutTarget != "" {
+		return s.layoutTarget
 	}
-	result := itoa(int(intPart)) + "."
-	if fracPart < 10 {
-		result += "0"
+	if s.presentation.pkg != nil {
+		return s.presentation.pkg.layoutTargetFor(s.layout)
 	}
-	result += itoa(int(fracPart))
-	return result
+	return "../slideLayouts/slideLayout" + itoa(builtinLayoutIndex(s.layout)+1) + ".xml"
+}
+
+// itoa 整数转字符串
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+// ftoa 浮点数转字符串，保留完整精度且不使用科学计数法
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// isFinite 是否为有效数值（不是 NaN 或无穷大）
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
 }
@@ -0,0 +1,92 @@
+package genppt
+
+// cellEdge 单元格的一条边
+type cellEdge int
+
+const (
+	edgeLeft cellEdge = iota
+	edgeRight
+	edgeTop
+	edgeBottom
+)
+
+// edgeBorder 返回单元格在某条边上设置的边框
+func (cell *TableCell) edgeBorder(edge cellEdge) *Border {
+	switch edge {
+	case edgeLeft:
+		return cell.BorderLeft
+	case edgeRight:
+		return cell.BorderRight
+	case edgeTop:
+		return cell.BorderTop
+	default:
+		return cell.BorderBottom
+	}
+}
+
+// cellAt 返回指定位置的单元格，超出范围时返回 nil
+func (t *tableObject) cellAt(row, col int) *TableCell {
+	if row < 0 || row >= len(t.rows) || col < 0 || col >= len(t.rows[row]) {
+		return nil
+	}
+	return &t.rows[row][col]
+}
+
+// cellBorder 返回单元格某条边实际使用的边框，依次使用单元格自身、相邻单元格的同一条边和表格边框
+// 相邻单元格共用一条边，两边写入相同的线条，避免 PowerPoint 显示不一致
+func (t *tableObject) cellBorder(row, col int, edge cellEdge) *Border {
+	if b := t.rows[row][col].edgeBorder(edge); b != nil {
+		return b
+	}
+	var neighbor *TableCell
+	var opposite cellEdge
+	switch edge {
+	case edgeLeft:
+		neighbor, opposite = t.cellAt(row, col-1), edgeRight
+	case edgeRight:
+		neighbor, opposite = t.cellAt(row, col+1), edgeLeft
+	case edgeTop:
+		neighbor, opposite = t.cellAt(row-1, col), edgeBottom
+	default:
+		neighbor, opposite = t.cellAt(row+1, col), edgeTop
+	}
+	if neighbor != nil {
+		if b := neighbor.edgeBorder(opposite); b != nil {
+			return b
+		}
+	}
+	if t.options.Border.Color != "" {
+		return &t.options.Border
+	}
+	return nil
+}
+
+// inherit 用表格边框补全单元格边框中未设置的颜色、宽度和样式
+func (fallback Border) inherit(b Border) Border {
+	b.Color = defaultIfEmpty(b.Color, defaultIfEmpty(fallback.Color, "000000"))
+	b.Width = defaultIfZero(b.Width, fallback.Width)
+	if b.Style == "" {
+		b.Style = fallback.Style
+	}
+	return b
+}
+
+// paragraphs 返回单元格的段落
+func (cell *TableCell) paragraphs() []Paragraph {
+	if len(cell.Paragraphs) > 0 {
+		return cell.Paragraphs
+	}
+	return textToParagraphs(cell.Text)
+}
+
+// cellTextOptions 返回单元格文字的默认格式，单元格的设置优先于表格
+func (t *tableObject) cellTextOptions(cell *TableCell, rowIdx int) TextOptions {
+	return TextOptions{
+		FontFace:  defaultIfEmpty(cell.FontFace, t.options.FontFace),
+		FontSize:  defaultIfZero(cell.FontSize, t.options.FontSize),
+		FontColor: defaultIfEmpty(cell.FontColor, t.options.FontColor),
+		Bold:      cell.Bold || (rowIdx == 0 && t.options.FirstRowBold),
+		Italic:    cell.Italic,
+		Align:     Align(defaultIfEmpty(string(cell.Align), string(AlignLeft))),
+	}
+}
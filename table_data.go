@@ -0,0 +1,314 @@
+package genppt
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableData 由 CSV、记录或结构体生成的表格，第一行为表头
+// 数值列（所有非空单元格都是数值）右对齐，可以直接用于 AddTableData 和 AddPagedTable
+type TableData struct {
+	Rows    [][]TableCell // 单元格，第一行为表头
+	Options TableOptions  // 表格选项
+}
+
+// TableFromRecords 从表头和文本记录创建表格，行的长度不一致时用空单元格补齐，opts 为表格选项
+func TableFromRecords(header []string, rows [][]string, opts TableOptions) *TableData {
+	numCols := len(header)
+	for _, row := range rows {
+		numCols = max(numCols, len(row))
+	}
+
+	data := &TableData{Rows: make([][]TableCell, 0, len(rows)+1), Options: opts}
+	for _, record := range append([][]string{header}, rows...) {
+		row := make([]TableCell, numCols)
+		for col := range row {
+			if col < len(record) {
+				row[col].Text = strings.TrimSpace(record[col])
+			}
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	data.alignNumbers(nil)
+	return data
+}
+
+// TableFromCSV 从 CSV 创建表格，第一行为表头，opts 为表格选项
+func TableFromCSV(r io.Reader, opts TableOptions) (*TableData, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("读取CSV失败: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("表格数据为空")
+	}
+	// Excel 导出的 CSV 可能带有 UTF-8 BOM
+	if len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+	}
+	return TableFromRecords(records[0], records[1:], opts), nil
+}
+
+// tableField 结构体字段对应的表格列
+type tableField struct {
+	index  int    // 字段序号
+	header string // 表头
+	align  Align  // 对齐方式，为空时数值列右对齐
+	format string // 格式：数值等使用 fmt 格式（如 "%.2f"），time.Time 使用时间布局（如 "2006-01-02"）
+}
+
+// TableFromStructs 从结构体切片创建表格，每个元素为一行
+// 字段通过 ppt 标签配置：`ppt:"金额,align=right,format=%.2f"` 设置表头、对齐方式和格式，`ppt:"-"` 忽略字段
+// 未设置表头时使用字段名；数值字段右对齐，time.Time 默认格式为 2006-01-02，nil 指针为空单元格；opts 为表格选项
+func TableFromStructs(slice any, opts TableOptions) (*TableData, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("需要结构体切片，实际为 %T", slice)
+	}
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("需要结构体切片，实际为 %T", slice)
+	}
+
+	// 解析字段
+	var fields []tableField
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("ppt")
+		if tag == "-" {
+			continue
+		}
+		f, err := parseTableTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("字段 %s: %w", field.Name, err)
+		}
+		f.index = i
+		f.header = defaultIfEmpty(f.header, field.Name)
+		if f.align == "" {
+			kind := field.Type.Kind()
+			if kind == reflect.Pointer {
+				kind = field.Type.Elem().Kind()
+			}
+			if isNumberKind(kind) {
+				f.align = AlignRight
+			}
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s 没有导出的字段", elemType.Name())
+	}
+
+	data := &TableData{Options: opts}
+	header := make([]TableCell, len(fields))
+	for i, f := range fields {
+		header[i] = TableCell{Text: f.header, Align: f.align}
+	}
+	data.Rows = append(data.Rows, header)
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		row := make([]TableCell, len(fields))
+		for j, f := range fields {
+			row[j] = TableCell{Text: formatTableValue(elem.Field(f.index), f.format), Align: f.align}
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	explicit := make([]bool, len(fields))
+	for i, f := range fields {
+		explicit[i] = f.align != ""
+	}
+	data.alignNumbers(explicit)
+	return data, nil
+}
+
+// parseTableTag 解析 ppt 标签：表头,align=对齐方式,format=格式
+// format 必须放在最后，其中可以包含逗号
+func parseTableTag(tag string) (tableField, error) {
+	var f tableField
+	header, rest, _ := strings.Cut(tag, ",")
+	f.header = header
+	for rest != "" {
+		var opt string
+		if strings.HasPrefix(rest, "format=") {
+			opt, rest = rest, ""
+		} else {
+			opt, rest, _ = strings.Cut(rest, ",")
+		}
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "align":
+			switch value {
+			case "left", "l":
+				f.align = AlignLeft
+			case "center", "ctr":
+				f.align = AlignCenter
+			case "right", "r":
+				f.align = AlignRight
+			case "justify", "just":
+				f.align = AlignJustify
+			default:
+				return f, fmt.Errorf("无效的对齐方式: %q", value)
+			}
+		case "format":
+			f.format = value
+		default:
+			return f, fmt.Errorf("无效的标签选项: %q", opt)
+		}
+	}
+	return f, nil
+}
+
+// formatTableValue 把字段的值格式化为单元格文本
+func formatTableValue(v reflect.Value, format string) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(defaultIfEmpty(format, "2006-01-02"))
+	}
+	if format != "" {
+		return fmt.Sprintf(format, v.Interface())
+	}
+	switch v.Kind() {
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// alignNumbers 把数值列（所有非空单元格都是数值）右对齐，表头一起右对齐
+// explicit 中为 true 的列已指定对齐方式，不做处理
+func (d *TableData) alignNumbers(explicit []bool) {
+	if len(d.Rows) < 2 {
+		return
+	}
+	for col := range d.Rows[0] {
+		if col < len(explicit) && explicit[col] {
+			continue
+		}
+		numeric := false
+		for _, row := range d.Rows[1:] {
+			if col >= len(row) || strings.TrimSpace(row[col].Text) == "" {
+				continue
+			}
+			if !isTableNumber(row[col].Text) {
+				numeric = false
+				break
+			}
+			numeric = true
+		}
+		if !numeric {
+			continue
+		}
+		for _, row := range d.Rows {
+			if col < len(row) && row[col].Align == "" {
+				row[col].Align = AlignRight
+			}
+		}
+	}
+}
+
+// isTableNumber 文本是否为十进制数值，可以带千位分隔符、百分号和货币符号
+// Inf、NaN 和十六进制等 strconv 也接受的写法不算数值
+func isTableNumber(s string) bool {
+	s = strings.TrimSpace(s)
+	s = strings.TrimLeft(s, "¥$€£")
+	if strings.HasPrefix(s, "-") {
+		s = strings.TrimLeft(s[1:], "¥$€£")
+	}
+	if strings.Trim(s, "0123456789.,%+-eE ") != "" || strings.IndexAny(s, "0123456789") < 0 {
+		return false
+	}
+	v, err := parseChartNumber(s)
+	return err == nil && !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// withAutoColWidths 开启 AutoColWidths 且未设置 ColWidths 时，按每列最长一行文字的宽度分配列宽
+func (opts TableOptions) withAutoColWidths(rows [][]TableCell) TableOptions {
+	if !opts.AutoColWidths || len(opts.ColWidths) > 0 || len(rows) == 0 || len(rows[0]) == 0 {
+		return opts
+	}
+	numCols := len(rows[0])
+	widths := make([]float64, numCols)
+	for _, row := range rows {
+		for col, cell := range row {
+			if col >= numCols || cell.ColSpan > 1 {
+				continue
+			}
+			for _, para := range cell.paragraphs() {
+				var text strings.Builder
+				for _, run := range para.Runs {
+					text.WriteString(run.Text)
+				}
+				for _, line := range strings.Split(text.String(), "\n") {
+					widths[col] = math.Max(widths[col], textEms(line))
+				}
+			}
+		}
+	}
+
+	// 每列留出约一个字的内边距，至少两个字宽
+	total := 0.0
+	for i := range widths {
+		widths[i] = math.Max(widths[i]+1, 2)
+		total += widths[i]
+	}
+	tableWidth := defaultIfZero(opts.Width, 8)
+	opts.ColWidths = make([]float64, numCols)
+	for i, w := range widths {
+		opts.ColWidths[i] = tableWidth * w / total
+	}
+	return opts
+}
+
+// textEms 估算文字的宽度（以字号为单位），中文等全角字符为1，其他为0.55
+func textEms(s string) float64 {
+	width := 0.0
+	for _, r := range s {
+		if r > 127 {
+			width += 1
+		} else {
+			width += 0.55
+		}
+	}
+	return width
+}
+
+// AddTableData 使用 TableData 添加表格，data 为 nil 时不添加
+func (s *Slide) AddTableData(data *TableData) *Slide {
+	if data == nil {
+		return s
+	}
+	return s.AddTable(data.Rows, data.Options)
+}
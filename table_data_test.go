@@ -0,0 +1,117 @@
+package genppt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTableFromCSV 测试从 CSV 和文本记录创建表格
+func TestTableFromCSV(t *testing.T) {
+	csvData := "\ufeff部门,人数,预算,备注\n研发,12,\"¥1,200.50\",核心\n市场,8,-300,\n运营,5\n"
+	data, err := TableFromCSV(strings.NewReader(csvData), TableOptions{Width: 8, Style: TableStyleLight1Accent1})
+	if err != nil {
+		t.Fatalf("TableFromCSV() 失败: %v", err)
+	}
+	if len(data.Rows) != 4 || data.Rows[0][0].Text != "部门" {
+		t.Fatalf("表头或行数错误: %v", data.Rows)
+	}
+	if len(data.Rows[3]) != 4 || data.Rows[3][2].Text != "" {
+		t.Error("较短的行应该用空单元格补齐")
+	}
+	if data.Options.Style != TableStyleLight1Accent1 {
+		t.Error("应该保留表格选项")
+	}
+	for col, align := range []Align{"", AlignRight, AlignRight, ""} {
+		for r, row := range data.Rows {
+			if row[col].Align != align {
+				t.Errorf("第%d行第%d列的对齐方式应该为 %q，实际为 %q", r+1, col+1, align, row[col].Align)
+			}
+		}
+	}
+
+	records := TableFromRecords([]string{"编号", "名称", "值"}, [][]string{{"001", "甲", "Inf"}, {"A02", "乙", "0x1p-2"}}, TableOptions{AutoColWidths: true})
+	if records.Rows[1][0].Align != "" {
+		t.Error("含有非数值的列不应该右对齐")
+	}
+	if records.Rows[1][2].Align != "" || records.Rows[2][2].Align != "" {
+		t.Error("Inf 和十六进制数不应该当作数值")
+	}
+	if !records.Options.AutoColWidths {
+		t.Error("应该保留表格选项")
+	}
+
+	if _, err := TableFromCSV(strings.NewReader(""), TableOptions{}); err == nil {
+		t.Error("空数据应该返回错误")
+	}
+}
+
+// TestTableFromStructs 测试从结构体切片创建表格
+func TestTableFromStructs(t *testing.T) {
+	type expense struct {
+		Item    string    `ppt:"项目"`
+		Amount  float64   `ppt:"金额,format=%.2f"`
+		Count   *int      `ppt:"数量"`
+		Date    time.Time `ppt:"日期"`
+		Month   time.Time `ppt:"月份,align=center,format=2006-01"`
+		Code    string    `ppt:",align=right"`
+		Ignored string    `ppt:"-"`
+		private int
+	}
+	count := 3
+	day := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	rows := []*expense{
+		{Item: "差旅", Amount: 1234.5, Count: &count, Date: day, Month: day, Code: "A1"},
+		nil,
+		{Item: "办公", Amount: 80},
+	}
+	data, err := TableFromStructs(rows, TableOptions{Width: 9, AutoColWidths: true})
+	if err != nil {
+		t.Fatalf("TableFromStructs() 失败: %v", err)
+	}
+
+	var headers []string
+	for _, cell := range data.Rows[0] {
+		headers = append(headers, cell.Text)
+	}
+	if strings.Join(headers, ",") != "项目,金额,数量,日期,月份,Code" {
+		t.Errorf("表头错误: %v", headers)
+	}
+	if len(data.Rows) != 3 {
+		t.Fatalf("nil 元素应该跳过，实际为 %d 行", len(data.Rows))
+	}
+	first := data.Rows[1]
+	if first[1].Text != "1234.50" || first[2].Text != "3" || first[3].Text != "2024-03-05" || first[4].Text != "2024-03" {
+		t.Errorf("格式化错误: %v", first)
+	}
+	second := data.Rows[2]
+	if second[2].Text != "" || second[3].Text != "" {
+		t.Error("nil 指针和零时间应该为空单元格")
+	}
+	if first[1].Align != AlignRight || data.Rows[0][2].Align != AlignRight || first[4].Align != AlignCenter || first[5].Align != AlignRight || first[0].Align != "" {
+		t.Error("数值列应该右对齐，标签中的对齐方式优先")
+	}
+
+	if _, err := TableFromStructs([]struct {
+		A int `ppt:"A,align=top"`
+	}{{1}}, TableOptions{}); err == nil {
+		t.Error("无效的对齐方式应该返回错误")
+	}
+	if _, err := TableFromStructs([]int{1}, TableOptions{}); err == nil {
+		t.Error("非结构体切片应该返回错误")
+	}
+
+	// 按文字长度分配列宽
+	slide := New().AddSlide().AddTableData(data)
+	widths := slide.objects[0].(*tableObject).options.ColWidths
+	if len(widths) != 6 {
+		t.Fatalf("应该计算6列的列宽，实际为 %v", widths)
+	}
+	sum := 0.0
+	for _, w := range widths {
+		sum += w
+	}
+	if sum < 8.99 || sum > 9.01 || widths[3] <= widths[2] {
+		t.Errorf("列宽应该按文字长度分配并等于表格宽度: %v", widths)
+	}
+}
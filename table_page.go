@@ -0,0 +1,219 @@
+package genppt
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PageOptions 分页表格选项
+type PageOptions struct {
+	HeaderRows      int         // 每页重复的表头行数，默认1
+	MaxHeight       float64     // 每页表格的最大高度（英寸），默认从 TableOptions.Y 到幻灯片底部留出0.5英寸
+	Title           string      // 每页的标题，为空时不添加；TableOptions.Y 在标题底边之上时表格移到标题下方
+	TitleOptions    TextOptions // 标题格式，默认在表格上方，24磅粗体
+	ContinuedSuffix string      // 续页标题的后缀，如 "（续）"，为空时续页使用相同标题
+	PageNumbers     bool        // 在表格右下方显示页码
+	PageFormat      string      // 页码格式，参数为当前页和总页数，默认 "%d / %d"
+}
+
+// AddPagedTable 添加表格，行数超出一页时自动拆分到多张幻灯片，每页重复表头
+// 行高按文字内容估算，合并的行不会拆开；返回创建的幻灯片
+func (p *Presentation) AddPagedTable(rows [][]TableCell, opts TableOptions, page PageOptions) []*Slide {
+	if page.HeaderRows <= 0 {
+		page.HeaderRows = 1
+	}
+	page.HeaderRows = min(page.HeaderRows, len(rows))
+
+	// 表格与标题重叠时移到标题下方，设置了最大高度时相应减小
+	slideHeight := float64(p.slideHeight) / EMUPerInch
+	if page.Title != "" {
+		title := page.titleOptions(opts)
+		if bottom := title.Y + title.Height; opts.Y < bottom {
+			if page.MaxHeight > 0 {
+				page.MaxHeight = math.Max(page.MaxHeight-(bottom-opts.Y), 0.5)
+			}
+			opts.Y = bottom
+		}
+	}
+	if page.MaxHeight <= 0 {
+		page.MaxHeight = slideHeight - opts.Y - 0.5
+	}
+
+	opts = opts.withAutoColWidths(rows)
+	heights := (&tableObject{rows: rows, options: opts}).measureRows()
+	header := rows[:page.HeaderRows]
+	headerHeight := 0.0
+	for _, h := range heights[:page.HeaderRows] {
+		headerHeight += h
+	}
+
+	// 按高度拆分数据行，每页至少一组
+	var pages [][2]int
+	start, used := page.HeaderRows, headerHeight
+	for _, group := range rowGroups(rows, page.HeaderRows) {
+		groupHeight := 0.0
+		for _, h := range heights[group[0]:group[1]] {
+			groupHeight += h
+		}
+		if used+groupHeight > page.MaxHeight && group[0] > start {
+			pages = append(pages, [2]int{start, group[0]})
+			start, used = group[0], headerHeight
+		}
+		used += groupHeight
+	}
+	pages = append(pages, [2]int{start, len(rows)})
+
+	slides := make([]*Slide, 0, len(pages))
+	for i, pr := range pages {
+		pageRows := make([][]TableCell, 0, len(header)+pr[1]-pr[0])
+		pageRows = append(pageRows, header...)
+		pageRows = append(pageRows, rows[pr[0]:pr[1]]...)
+
+		pageOpts := opts
+		pageOpts.RowHeights = append(append([]float64{}, heights[:page.HeaderRows]...), heights[pr[0]:pr[1]]...)
+		if i < len(pages)-1 {
+			// 汇总行只在最后一页
+			pageOpts.TotalRow = false
+		}
+
+		slide := p.AddSlide()
+		if page.Title != "" {
+			title := page.Title
+			if i > 0 {
+				title += page.ContinuedSuffix
+			}
+			slide.AddText(title, page.titleOptions(opts))
+		}
+		slide.AddTable(pageRows, pageOpts)
+		if page.PageNumbers {
+			format := defaultIfEmpty(page.PageFormat, "%d / %d")
+			slide.AddText(fmt.Sprintf(format, i+1, len(pages)), TextOptions{
+				X:         opts.X,
+				Y:         math.Min(opts.Y+page.MaxHeight+0.05, slideHeight-0.45),
+				Width:     defaultIfZero(opts.Width, 8),
+				Height:    0.4,
+				FontSize:  12,
+				FontColor: "808080",
+				Align:     AlignRight,
+			})
+		}
+		slides = append(slides, slide)
+	}
+	return slides
+}
+
+// titleOptions 返回标题格式，未设置的位置和字体使用默认值
+func (page PageOptions) titleOptions(table TableOptions) TextOptions {
+	opts := page.TitleOptions
+	if opts.X == 0 && opts.Y == 0 {
+		opts.X = table.X
+		opts.Y = 0.3
+	}
+	opts.Width = defaultIfZero(opts.Width, defaultIfZero(table.Width, 8))
+	opts.Height = defaultIfZero(opts.Height, 0.8)
+	if opts.FontSize == 0 {
+		opts.FontSize = 24
+		opts.Bold = true
+	}
+	return opts
+}
+
+// rowGroups 把表头之后的行分组，纵向合并的行在同一组，返回每组的起止行 [start, end)
+func rowGroups(rows [][]TableCell, headerRows int) [][2]int {
+	var groups [][2]int
+	for r := headerRows; r < len(rows); {
+		end := r + 1
+		for i := r; i < end && i < len(rows); i++ {
+			for _, cell := range rows[i] {
+				end = max(end, i+cell.RowSpan)
+			}
+		}
+		end = min(end, len(rows))
+		groups = append(groups, [2]int{r, end})
+		r = end
+	}
+	return groups
+}
+
+// measureRows 估算每行的高度（英寸）：取设置的行高和单元格文字高度中较大的值
+func (t *tableObject) measureRows() []float64 {
+	numCols := 0
+	if len(t.rows) > 0 {
+		numCols = len(t.rows[0])
+	}
+	colWidths := make([]float64, numCols)
+	for i := range colWidths {
+		if len(t.options.ColWidths) >= numCols {
+			colWidths[i] = t.options.ColWidths[i]
+		} else {
+			colWidths[i] = defaultIfZero(t.options.Width, 8) / float64(numCols)
+		}
+	}
+
+	heights := make([]float64, len(t.rows))
+	for r, row := range t.rows {
+		height := 0.4
+		if r < len(t.options.RowHeights) {
+			height = t.options.RowHeights[r]
+		} else if len(t.options.RowHeights) > 0 {
+			height = t.options.RowHeights[0]
+		}
+		for c := range row {
+			if c >= numCols {
+				break
+			}
+			cell := &row[c]
+			if cell.RowSpan > 1 {
+				continue
+			}
+			width := 0.0
+			for i := c; i < min(c+max(cell.ColSpan, 1), numCols); i++ {
+				width += colWidths[i]
+			}
+			height = math.Max(height, t.cellHeight(cell, r, width))
+		}
+		heights[r] = height
+	}
+	return heights
+}
+
+// cellHeight 估算单元格文字的高度（英寸），width 为单元格宽度
+func (t *tableObject) cellHeight(cell *TableCell, rowIdx int, width float64) float64 {
+	opts := t.cellTextOptions(cell, rowIdx)
+	fontSize := defaultIfZero(opts.FontSize, 14)
+	marginX, marginY := 0.2, 0.1
+	if m := cell.Margins; m != nil {
+		marginX = defaultIfZero(m.Left, 0.1) + defaultIfZero(m.Right, 0.1)
+		marginY = defaultIfZero(m.Top, 0.05) + defaultIfZero(m.Bottom, 0.05)
+	}
+	if cell.TextDirection != "" && cell.TextDirection != TextHorizontal {
+		// 竖排文字的高度按最长段落的文字长度估算
+		longest := 0
+		for _, para := range cell.paragraphs() {
+			n := 0
+			for _, run := range para.Runs {
+				n += len([]rune(run.Text))
+			}
+			longest = max(longest, n)
+		}
+		return float64(longest)*fontSize/72*1.1 + marginY
+	}
+
+	height := marginY
+	for _, para := range cell.paragraphs() {
+		size := fontSize
+		var text strings.Builder
+		for _, run := range para.Runs {
+			size = math.Max(size, run.FontSize)
+			text.WriteString(run.Text)
+		}
+		lineHeight := size / 72 * 1.2 * defaultIfZero(para.LineSpacing, 1)
+		for _, line := range strings.Split(text.String(), "\n") {
+			_, lines := estimateLines(line, size, width-marginX)
+			height += float64(lines) * lineHeight
+		}
+		height += (para.SpaceBefore + para.SpaceAfter) / 72
+	}
+	return height
+}
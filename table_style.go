@@ -0,0 +1,242 @@
+package genppt
+
+import (
+	"strings"
+)
+
+// CustomTableStyle 自定义表格样式，写入 ppt/tableStyles.xml
+// 各部分按 PowerPoint 的优先级叠加：整个表格 < 镶边行/列 < 第一列/最后一列 < 汇总行 < 标题行
+type CustomTableStyle struct {
+	Name          string         // 样式名称，显示在 PowerPoint 的表格样式库中
+	WholeTable    TableStylePart // 整个表格
+	BandedRows    TableStylePart // 镶边行中的奇数行
+	BandedColumns TableStylePart // 镶边列中的奇数列
+	HeaderRow     TableStylePart // 标题行
+	TotalRow      TableStylePart // 汇总行
+	FirstColumn   TableStylePart // 第一列
+	LastColumn    TableStylePart // 最后一列
+}
+
+// TableStylePart 表格样式中一个部分的格式
+// 颜色可以使用主题颜色（如 "accent1"），切换主题后随之改变
+type TableStylePart struct {
+	Fill        string // 单元格背景色
+	FontColor   string // 文字颜色
+	Bold        bool   // 文字加粗
+	Border      Border // 该部分的外边框
+	InnerBorder Border // 该部分内部的横线和竖线
+}
+
+// customTableStyle 已添加的自定义表格样式
+type customTableStyle struct {
+	id    TableStyle
+	style CustomTableStyle
+}
+
+// AddTableStyle 添加自定义表格样式，返回的样式可以用于 TableOptions.Style
+// 打开的现有文件中样式追加到原有的 ppt/tableStyles.xml，文件中没有时新建
+func (p *Presentation) AddTableStyle(style CustomTableStyle) TableStyle {
+	id := TableStyle("{" + strings.ToUpper(generateUUID()) + "}")
+	style.Name = defaultIfEmpty(style.Name, "Custom Table Style "+itoa(len(p.tableStyles)+1))
+	p.tableStyles = append(p.tableStyles, customTableStyle{id: id, style: style})
+	return id
+}
+
+// generateTableProps 生成表格属性 a:tblPr
+func generateTableProps(opts TableOptions) string {
+	flags := []struct {
+		name string
+		on   bool
+	}{
+		{"firstRow", opts.HeaderRow == nil || *opts.HeaderRow},
+		{"firstCol", opts.FirstColumn},
+		{"lastRow", opts.TotalRow},
+		{"lastCol", opts.LastColumn},
+		{"bandRow", opts.BandedRows == nil || *opts.BandedRows},
+		{"bandCol", opts.BandedColumns},
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<a:tblPr`)
+	for _, f := range flags {
+		if f.on {
+			sb.WriteString(` `)
+			sb.WriteString(f.name)
+			sb.WriteString(`="1"`)
+		}
+	}
+	sb.WriteString(`>`)
+	sb.WriteString(`<a:tableStyleId>`)
+	style := opts.Style
+	if style == "" {
+		style = TableStyleMedium2Accent1
+	}
+	sb.WriteString(string(style))
+	sb.WriteString(`</a:tableStyleId>`)
+	sb.WriteString(`</a:tblPr>`)
+	return sb.String()
+}
+
+// generateTableStyleList 生成自定义表格样式 a:tblStyle 列表
+func (p *Presentation) generateTableStyleList() string {
+	var sb strings.Builder
+	for _, ts := range p.tableStyles {
+		st := ts.style
+		sb.WriteString(`<a:tblStyle styleId="`)
+		sb.WriteString(string(ts.id))
+		sb.WriteString(`" styleName="`)
+		sb.WriteString(escapeXML(st.Name))
+		sb.WriteString(`">`)
+		// 各部分的顺序由 schema 规定
+		parts := []struct {
+			tag  string
+			part TableStylePart
+		}{
+			{"wholeTbl", st.WholeTable},
+			{"band1H", st.BandedRows},
+			{"band1V", st.BandedColumns},
+			{"lastCol", st.LastColumn},
+			{"firstCol", st.FirstColumn},
+			{"lastRow", st.TotalRow},
+			{"firstRow", st.HeaderRow},
+		}
+		for _, part := range parts {
+			sb.WriteString(generateTableStylePart(part.tag, part.part))
+		}
+		sb.WriteString(`</a:tblStyle>`)
+	}
+	return sb.String()
+}
+
+// generateTableStylePart 生成表格样式的一个部分，未设置任何格式时返回空字符串
+func generateTableStylePart(tag string, part TableStylePart) string {
+	hasText := part.FontColor != "" || part.Bold
+	hasBorder := part.Border.Color != "" || part.InnerBorder.Color != ""
+	if !hasText && !hasBorder && part.Fill == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<a:`)
+	sb.WriteString(tag)
+	sb.WriteString(`>`)
+	if hasText {
+		sb.WriteString(`<a:tcTxStyle`)
+		if part.Bold {
+			sb.WriteString(` b="on"`)
+		}
+		sb.WriteString(`>`)
+		if part.FontColor != "" {
+			sb.WriteString(colorElement(part.FontColor, ""))
+		}
+		sb.WriteString(`</a:tcTxStyle>`)
+	}
+	sb.WriteString(`<a:tcStyle>`)
+	if hasBorder {
+		sb.WriteString(`<a:tcBdr>`)
+		edges := []struct {
+			tag    string
+			border Border
+		}{
+			{"left", part.Border},
+			{"right", part.Border},
+			{"top", part.Border},
+			{"bottom", part.Border},
+			{"insideH", part.InnerBorder},
+			{"insideV", part.InnerBorder},
+		}
+		for _, e := range edges {
+			if e.border.Color == "" {
+				continue
+			}
+			sb.WriteString(`<a:`)
+			sb.WriteString(e.tag)
+			sb.WriteString(`>`)
+			sb.WriteString(tableLine("a:ln", e.border))
+			sb.WriteString(`</a:`)
+			sb.WriteString(e.tag)
+			sb.WriteString(`>`)
+		}
+		sb.WriteString(`</a:tcBdr>`)
+	}
+	if part.Fill != "" {
+		sb.WriteString(`<a:fill>`)
+		sb.WriteString(solidFill(part.Fill))
+		sb.WriteString(`</a:fill>`)
+	}
+	sb.WriteString(`</a:tcStyle>`)
+	sb.WriteString(`</a:`)
+	sb.WriteString(tag)
+	sb.WriteString(`>`)
+	return sb.String()
+}
+
+// tableLine 生成表格线条，tag 为 a:ln、a:lnL 等，宽度默认1磅
+func tableLine(tag string, border Border) string {
+	var sb strings.Builder
+	sb.WriteString(`<`)
+	sb.WriteString(tag)
+	if border.Style == BorderNone || border.Color == "none" {
+		sb.WriteString(`><a:noFill/></`)
+		sb.WriteString(tag)
+		sb.WriteString(`>`)
+		return sb.String()
+	}
+	sb.WriteString(` w="`)
+	sb.WriteString(itoa(int(defaultIfZero(border.Width, 1) * EMUPerPoint)))
+	sb.WriteString(`" cap="flat" cmpd="`)
+	style := defaultIfEmpty(string(border.Style), string(BorderSolid))
+	if border.Style == BorderDouble {
+		sb.WriteString(`dbl`)
+		style = string(BorderSolid)
+	} else {
+		sb.WriteString(`sng`)
+	}
+	sb.WriteString(`" algn="ctr">`)
+	sb.WriteString(solidFill(border.Color))
+	sb.WriteString(`<a:prstDash val="`)
+	sb.WriteString(style)
+	sb.WriteString(`"/>`)
+	sb.WriteString(`</`)
+	sb.WriteString(tag)
+	sb.WriteString(`>`)
+	return sb.String()
+}
+
+// needsTableStylesPart 打开的文件没有 ppt/tableStyles.xml 且添加了自定义样式时，需要新建该部件
+func (p *Presentation) needsTableStylesPart() bool {
+	if p.pkg == nil || len(p.tableStyles) == 0 {
+		return false
+	}
+	_, ok := p.pkg.parts["ppt/tableStyles.xml"]
+	return !ok
+}
+
+// tableStylesRelID 返回新建的 tableStyles.xml 在 presentation.xml.rels 中的关系ID，排在生成的备注母版之后
+func (p *Presentation) tableStylesRelID() string {
+	id := p.notesMasterRelID()
+	if p.needsNotesMaster() {
+		id = "rId" + itoa(atoi(strings.TrimPrefix(id, "rId"))+1)
+	}
+	return id
+}
+
+// applyTableStyles 把自定义表格样式追加到现有的 tableStyles.xml
+func (p *Presentation) applyTableStyles(data []byte) []byte {
+	styles := p.generateTableStyleList()
+	xmlStr := string(data)
+	if i := strings.LastIndex(xmlStr, "</a:tblStyleLst>"); i >= 0 {
+		return []byte(xmlStr[:i] + styles + xmlStr[i:])
+	}
+	// 空列表 <a:tblStyleLst .../>
+	start := strings.Index(xmlStr, "<a:tblStyleLst")
+	if start < 0 {
+		return data
+	}
+	end := strings.Index(xmlStr[start:], "/>")
+	if end < 0 {
+		return data
+	}
+	end += start
+	return []byte(xmlStr[:end] + ">" + styles + "</a:tblStyleLst>" + xmlStr[end+2:])
+}
@@ -0,0 +1,315 @@
+package genppt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestTableStyles 测试内置表格样式、样式开关和自定义样式
+func TestTableStyles(t *testing.T) {
+	rows := [][]TableCell{
+		{{Text: "项目"}, {Text: "金额"}},
+		{{Text: "收入"}, {Text: "100"}},
+		{{Text: "合计"}, {Text: "100"}},
+	}
+
+	pres := New()
+	custom := pres.AddTableStyle(CustomTableStyle{
+		Name:       "财务",
+		WholeTable: TableStylePart{FontColor: "dk1", InnerBorder: Border{Color: "D9D9D9", Width: 0.5}},
+		BandedRows: TableStylePart{Fill: "F2F2F2"},
+		HeaderRow:  TableStylePart{Fill: "accent1", FontColor: "FFFFFF", Bold: true},
+		TotalRow:   TableStylePart{Bold: true, Border: Border{Color: "000000", Width: 1.5}},
+	})
+	if !strings.HasPrefix(string(custom), "{") || len(custom) != 38 {
+		t.Fatalf("自定义样式ID应该是带花括号的GUID: %s", custom)
+	}
+
+	slide := pres.AddSlide()
+	slide.AddTable(rows, TableOptions{})
+	slide.AddTable(rows, TableOptions{Style: TableStyleMedium2Accent2, TotalRow: true, FirstColumn: true, BandedColumns: true})
+	slide.AddTable(rows, TableOptions{Style: custom, BandedRows: Bool(false), TotalRow: true})
+	slide.AddTable(rows, TableOptions{Style: TableStyleLight1Accent1, HeaderRow: Bool(false), BandedRows: Bool(false)})
+
+	legacy := slide.objects[0].(*tableObject)
+	styled := slide.objects[1].(*tableObject)
+	if legacy.options.Border.Color != "CCCCCC" || styled.options.Border.Color != "" || styled.options.FontColor != "" {
+		t.Error("只有未设置样式的表格才添加默认边框和字体颜色")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+
+	slideXML := string(parts["ppt/slides/slide1.xml"])
+	for _, s := range []string{
+		`<a:tblPr firstRow="1" bandRow="1"><a:tableStyleId>{5C22544A-7EE6-4342-B048-85BDC9FD1C3A}</a:tableStyleId></a:tblPr>`,
+		// 只设置其他开关时仍保留默认的标题行和镶边行
+		`<a:tblPr firstRow="1" firstCol="1" lastRow="1" bandRow="1" bandCol="1"><a:tableStyleId>{21E4AEA4-8DFA-4A89-87EB-49C32662AFE8}</a:tableStyleId></a:tblPr>`,
+		`<a:tblPr firstRow="1" lastRow="1"><a:tableStyleId>` + string(custom) + `</a:tableStyleId></a:tblPr>`,
+		`<a:tblPr><a:tableStyleId>` + string(TableStyleLight1Accent1) + `</a:tableStyleId></a:tblPr>`,
+	} {
+		if !strings.Contains(slideXML, s) {
+			t.Errorf("slide1.xml 应该包含 %s", s)
+		}
+	}
+	if strings.Count(slideXML, `<a:lnL `) != 6 {
+		t.Error("只有未设置样式的表格的单元格应该有边框")
+	}
+
+	stylesXML := string(parts["ppt/tableStyles.xml"])
+	for _, s := range []string{
+		`<a:tblStyle styleId="` + string(custom) + `" styleName="财务">`,
+		`<a:wholeTbl><a:tcTxStyle><a:schemeClr val="dk1"/></a:tcTxStyle><a:tcStyle><a:tcBdr><a:insideH><a:ln w="6350"`,
+		`<a:band1H><a:tcStyle><a:fill><a:solidFill><a:srgbClr val="F2F2F2"/></a:solidFill></a:fill></a:tcStyle></a:band1H>`,
+		`</a:lastRow><a:firstRow><a:tcTxStyle b="on"><a:srgbClr val="FFFFFF"/></a:tcTxStyle>`,
+		`<a:solidFill><a:schemeClr val="accent1"/></a:solidFill>`,
+	} {
+		if !strings.Contains(stylesXML, s) {
+			t.Errorf("tableStyles.xml 应该包含 %s", s)
+		}
+	}
+	if strings.Contains(stylesXML, "<a:band1V>") {
+		t.Error("未设置的部分不应该输出")
+	}
+
+	// 打开的文件：自定义样式追加到原有的 tableStyles.xml
+	sample := buildSampleDeck(t)
+	opened, err := OpenReader(bytes.NewReader(sample), int64(len(sample)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	id := opened.AddTableStyle(CustomTableStyle{HeaderRow: TableStylePart{Fill: "C00000"}})
+	out, err := opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts = readZipParts(t, out)
+	checkWellFormed(t, parts)
+	if !strings.Contains(string(parts["ppt/tableStyles.xml"]), `<a:tblStyle styleId="`+string(id)+`" styleName="Custom Table Style 1">`) {
+		t.Errorf("打开的文件应该追加自定义样式: %s", parts["ppt/tableStyles.xml"])
+	}
+
+	// 打开的文件没有 tableStyles.xml 时新建部件、内容类型和关系
+	opened, err = OpenReader(bytes.NewReader(sample), int64(len(sample)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	delete(opened.pkg.parts, "ppt/tableStyles.xml")
+	delete(opened.pkg.overrides, "ppt/tableStyles.xml")
+	for i, rel := range opened.pkg.presRels {
+		if rel.relType == relTypeTableStyles {
+			opened.pkg.presRels = append(opened.pkg.presRels[:i], opened.pkg.presRels[i+1:]...)
+			break
+		}
+	}
+	id = opened.AddTableStyle(CustomTableStyle{HeaderRow: TableStylePart{Fill: "C00000"}})
+	out, err = opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts = readZipParts(t, out)
+	checkWellFormed(t, parts)
+	if !strings.Contains(string(parts["ppt/tableStyles.xml"]), `<a:tblStyle styleId="`+string(id)+`"`) {
+		t.Errorf("应该新建包含自定义样式的 tableStyles.xml: %s", parts["ppt/tableStyles.xml"])
+	}
+	if !strings.Contains(string(parts["[Content_Types].xml"]), `<Override PartName="/ppt/tableStyles.xml"`) {
+		t.Error("新建的 tableStyles.xml 应该有内容类型")
+	}
+	presRels := string(parts["ppt/_rels/presentation.xml.rels"])
+	if strings.Count(presRels, relTypeTableStyles) != 1 || strings.Count(presRels, `Id="`+opened.tableStylesRelID()+`"`) != 1 {
+		t.Errorf("新建的 tableStyles.xml 应该有唯一的关系: %s", presRels)
+	}
+}
+
+// TestTableCellBorders 测试单元格边框、斜线和内边距
+func TestTableCellBorders(t *testing.T) {
+	none := &Border{Style: BorderNone}
+	total := &Border{Color: "000000", Width: 2.25, Style: BorderDouble}
+	rows := [][]TableCell{
+		{{Text: "项目", DiagonalDown: &Border{Color: "808080"}}, {Text: "金额", BorderLeft: none}},
+		{{Text: "收入"}, {Text: "100", Margins: &CellMargins{Left: 0.05, Right: 0.2}}},
+		{{Text: "合计", BorderTop: total}, {Text: "100", BorderTop: total, BorderRight: &Border{Width: 3}}},
+	}
+	table := &tableObject{rows: rows, options: TableOptions{Border: Border{Color: "CCCCCC", Width: 1, Style: BorderSolid}}}
+
+	if b := table.cellBorder(0, 0, edgeRight); b != none {
+		t.Error("右边框应该使用相邻单元格的左边框")
+	}
+	if b := table.cellBorder(1, 1, edgeBottom); b != total {
+		t.Error("下边框应该使用下方单元格的上边框")
+	}
+	if b := table.cellBorder(1, 0, edgeLeft); b == nil || b.Color != "CCCCCC" {
+		t.Error("未设置的边框应该使用表格边框")
+	}
+	if b := table.options.Border.inherit(*rows[2][1].BorderRight); b.Color != "CCCCCC" || b.Width != 3 || b.Style != BorderSolid {
+		t.Errorf("单元格边框应该用表格边框补全: %+v", b)
+	}
+
+	slide := New().AddSlide()
+	slide.AddTable(rows, TableOptions{})
+	xmlStr := slide.generateTable(slide.objects[0].(*tableObject), 2)
+	for _, s := range []string{
+		`<a:lnR><a:noFill/></a:lnR><a:lnT w="12700"`,
+		`<a:lnTlToBr w="12700" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:srgbClr val="808080"/></a:solidFill><a:prstDash val="solid"/></a:lnTlToBr>`,
+		`<a:lnB w="28575" cap="flat" cmpd="dbl" algn="ctr"><a:solidFill><a:srgbClr val="000000"/></a:solidFill><a:prstDash val="solid"/></a:lnB>`,
+		`<a:tcPr marL="45720" marR="182880" anchor="ctr">`,
+		`<a:lnR w="38100" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:srgbClr val="CCCCCC"/>`,
+	} {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("表格应该包含 %s", s)
+		}
+	}
+
+	// 使用表格样式时只输出单元格自己的边框
+	slide.AddTable(rows, TableOptions{Style: TableStyleMedium2})
+	xmlStr = slide.generateTable(slide.objects[1].(*tableObject), 3)
+	if strings.Count(xmlStr, "<a:lnL") != 1 || strings.Count(xmlStr, "<a:lnT ") != 2 {
+		t.Error("使用表格样式时不应该输出表格边框")
+	}
+}
+
+// TestTableRichCells 测试单元格中的多段落、混合格式、项目符号、链接和文字方向
+func TestTableRichCells(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide()
+	slide.AddTable([][]TableCell{
+		{
+			{Text: "季度", TextDirection: TextEastAsianVertical},
+			{Paragraphs: []Paragraph{{Runs: []TextRun{{Text: "收入"}, {Text: "1", Superscript: true}}}}},
+		},
+		{
+			{Text: "第一行\n第二行"},
+			{Paragraphs: []Paragraph{
+				{Runs: []TextRun{{Text: "要点", Bold: Bool(true)}}, Align: AlignCenter},
+				{Runs: []TextRun{{Text: "增长"}}, Bullet: "•"},
+				{Runs: []TextRun{{Text: "详见"}, {Text: "附录", Link: &Hyperlink{URL: "https://example.com"}}}, Numbered: true, Level: 1},
+				{Runs: []TextRun{{Text: "甲\n乙", FontColor: "C00000"}}},
+			}},
+		},
+	}, TableOptions{FontSize: 12, FirstRowBold: true, Style: TableStyleMedium2})
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+
+	slideXML := string(parts["ppt/slides/slide1.xml"])
+	for _, s := range []string{
+		`<a:tcPr vert="eaVert" anchor="ctr">`,
+		`<a:rPr lang="zh-CN" sz="1200" b="1" baseline="30000">`,
+		`<a:t>第一行</a:t></a:r><a:endParaRPr lang="zh-CN"/></a:p><a:p><a:pPr algn="l"></a:pPr>`,
+		`<a:pPr algn="ctr"></a:pPr><a:r><a:rPr lang="zh-CN" sz="1200" b="1">`,
+		`<a:pPr marL="228600" indent="-228600" algn="l"><a:buFont typeface="Arial"/><a:buChar char="•"/></a:pPr>`,
+		`<a:pPr marL="685800" indent="-228600" lvl="1" algn="l"><a:buAutoNum type="arabicPeriod"/></a:pPr>`,
+		`<a:hlinkClick r:id="rIdL1"/>`,
+		`<a:br><a:rPr lang="zh-CN" sz="1200"><a:solidFill><a:srgbClr val="C00000"/>`,
+	} {
+		if !strings.Contains(slideXML, s) {
+			t.Errorf("slide1.xml 应该包含 %s", s)
+		}
+	}
+	if !strings.Contains(string(parts["ppt/slides/_rels/slide1.xml.rels"]), `Target="https://example.com"`) {
+		t.Error("单元格中的链接应该添加关系")
+	}
+}
+
+// TestTableReplaceText 测试替换富文本单元格中的文本
+func TestTableReplaceText(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide()
+	rows := [][]TableCell{
+		{{Text: "{{name}}"}, {Paragraphs: []Paragraph{{Runs: []TextRun{{Text: "姓名："}, {Text: "{{name}}", Bold: Bool(true)}}}}}},
+	}
+	slide.AddTable(rows, TableOptions{})
+
+	if n := slide.ReplaceText("{{name}}", "张三"); n != 2 {
+		t.Errorf("应该替换 2 处，实际替换 %d 处", n)
+	}
+	xmlStr := slide.generateTable(slide.objects[0].(*tableObject), 2)
+	if strings.Contains(xmlStr, "{{name}}") || strings.Count(xmlStr, "<a:t>张三</a:t>") != 2 {
+		t.Errorf("单元格中的占位符应该全部替换: %s", xmlStr)
+	}
+	if rows[0][0].Text != "{{name}}" || rows[0][1].Paragraphs[0].Runs[1].Text != "{{name}}" {
+		t.Error("替换文本不应修改调用方的单元格")
+	}
+}
+
+// TestAddPagedTable 测试表格自动分页
+func TestAddPagedTable(t *testing.T) {
+	rows := [][]TableCell{{{Text: "序号"}, {Text: "说明"}}}
+	for i := 1; i <= 30; i++ {
+		rows = append(rows, []TableCell{{Text: itoa(i)}, {Text: "记录"}})
+	}
+	// 纵向合并的行不拆开
+	rows[10][0].RowSpan = 3
+	rows = append(rows, []TableCell{{Text: "合计"}, {Text: "30"}})
+
+	pres := New()
+	slides := pres.AddPagedTable(rows, TableOptions{X: 0.5, Y: 1.2, Width: 9, TotalRow: true, Style: TableStyleMedium2Accent1}, PageOptions{
+		Title:           "审计明细",
+		ContinuedSuffix: "（续）",
+		PageNumbers:     true,
+	})
+	if len(slides) < 2 || pres.SlideCount() != len(slides) {
+		t.Fatalf("应该拆分为多张幻灯片，实际为 %d 张", len(slides))
+	}
+
+	total := 0
+	for i, slide := range slides {
+		title := slide.objects[0].(*textObject)
+		table := slide.objects[1].(*tableObject)
+		counter := slide.objects[2].(*textObject)
+		if table.rows[0][0].Text != "序号" {
+			t.Errorf("第%d页应该重复表头", i+1)
+		}
+		if (i == 0) != (title.text == "审计明细") || (i > 0 && title.text != "审计明细（续）") {
+			t.Errorf("第%d页标题错误: %s", i+1, title.text)
+		}
+		if counter.text != itoa(i+1)+" / "+itoa(len(slides)) {
+			t.Errorf("第%d页页码错误: %s", i+1, counter.text)
+		}
+		height := 0.0
+		for _, h := range table.options.RowHeights {
+			height += h
+		}
+		if len(table.options.RowHeights) != len(table.rows) || height > float64(DefaultSlideHeight)/EMUPerInch-1.2-0.5 {
+			t.Errorf("第%d页行高错误，总高度 %.2f", i+1, height)
+		}
+		if table.options.TotalRow != (i == len(slides)-1) {
+			t.Errorf("汇总行只应该在最后一页")
+		}
+		for r, row := range table.rows[1:] {
+			if row[0].RowSpan == 3 && r+3 > len(table.rows)-1 {
+				t.Error("纵向合并的行不应该拆到两页")
+			}
+		}
+		total += len(table.rows) - 1
+	}
+	if total != len(rows)-1 {
+		t.Errorf("所有数据行都应该输出，实际为 %d 行", total)
+	}
+
+	// 未设置表格位置时移到标题下方，页码不超出幻灯片
+	pres = New()
+	slides = pres.AddPagedTable(rows, TableOptions{}, PageOptions{Title: "审计明细", PageNumbers: true, MaxHeight: 20})
+	table := slides[0].objects[1].(*tableObject)
+	if table.options.Y < 1.1 {
+		t.Errorf("表格不应该与标题重叠，实际 Y 为 %.2f", table.options.Y)
+	}
+	if counter := slides[0].objects[2].(*textObject); counter.options.Y+counter.options.Height > float64(DefaultSlideHeight)/EMUPerInch {
+		t.Errorf("页码超出幻灯片，Y 为 %.2f", counter.options.Y)
+	}
+
+	// 长文本增加行高
+	long := &tableObject{rows: [][]TableCell{{{Text: "短"}, {Text: strings.Repeat("很长的说明文字", 20)}}}, options: TableOptions{Width: 6, FontSize: 12}}
+	if h := long.measureRows()[0]; h <= 0.4 {
+		t.Errorf("长文本的行高应该大于默认行高，实际为 %.2f", h)
+	}
+}
@@ -0,0 +1,91 @@
+package genppt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// NewFromTemplate 基于模板文件（.potx/.pptx）创建演示文稿
+// 沿用模板的主题、母版和布局，模板中已有的幻灯片不会保留
+func NewFromTemplate(filename string) (*Presentation, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromTemplateReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// NewFromTemplateReader 从io.ReaderAt读取模板并创建演示文稿
+func NewFromTemplateReader(r io.ReaderAt, size int64) (*Presentation, error) {
+	p, err := OpenReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// 新文稿不沿用模板的幻灯片和文档属性
+	p.slides = p.slides[:0]
+	p.title = ""
+	p.author = ""
+	p.subject = ""
+	p.company = ""
+	p.pkg.prune(p.slides)
+	return p, nil
+}
+
+// layoutTargetFor 返回与 SlideLayout 类型相符的模板布局，找不到时使用默认布局
+func (pkg *sourcePackage) layoutTargetFor(layout SlideLayout) string {
+	if l := pkg.findLayoutByType(builtinLayouts[builtinLayoutIndex(layout)].layoutType); l != nil {
+		return l.relTarget()
+	}
+	return pkg.defaultLayoutTarget()
+}
+
+// LayoutNames 返回可用的布局名称（模板或打开文件中的布局，否则为内置布局）
+func (p *Presentation) LayoutNames() []string {
+	if p.pkg == nil {
+		names := make([]string, 0, len(builtinLayouts))
+		for _, l := range builtinLayouts {
+			names = append(names, l.name)
+		}
+		return names
+	}
+	names := make([]string, 0, len(p.pkg.layouts))
+	for _, l := range p.pkg.layouts {
+		names = append(names, l.name)
+	}
+	return names
+}
+
+// AddSlideWithLayout 使用指定名称的布局添加幻灯片（如 "Title and Content"）
+// 找不到同名布局时使用默认布局，并在保存时返回错误
+func (p *Presentation) AddSlideWithLayout(name string) *Slide {
+	return p.AddSlide().SetLayoutName(name)
+}
+
+// SetLayoutName 按名称设置幻灯片使用的布局
+// 找不到同名布局时保持原有布局，错误在 Write、WriteFile 或 ToBytes 时返回
+func (s *Slide) SetLayoutName(name string) *Slide {
+	if s.presentation.pkg == nil {
+		for _, l := range builtinLayouts {
+			if strings.EqualFold(l.name, name) {
+				s.layout = l.layout
+				return s
+			}
+		}
+	} else if layout := s.presentation.pkg.findLayoutByName(name); layout != nil {
+		s.layoutTarget = layout.relTarget()
+		return s
+	}
+	s.presentation.setError(fmt.Errorf("找不到布局: %q", name))
+	return s
+}
+
+// setError 记录第一个错误，保存时返回
+func (p *Presentation) setError(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
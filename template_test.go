@@ -0,0 +1,192 @@
+package genppt
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// buildTemplate 生成带有英文布局名称和一张示例幻灯片的模板
+func buildTemplate(t *testing.T) []byte {
+	t.Helper()
+	pres := New()
+	pres.SetTitle("模板标题")
+	pres.AddSlide().AddImage(ImageOptions{Data: []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}})
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+
+	parts["ppt/slideLayouts/slideLayout3.xml"] = []byte(strings.Replace(string(parts["ppt/slideLayouts/slideLayout3.xml"]),
+		`name="标题和内容"`, `name="Title and Content"`, 1))
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, _ := zipWriter.Create(name)
+		w.Write(content)
+	}
+	zipWriter.Close()
+	return buf.Bytes()
+}
+
+// TestNewFromTemplate 测试基于模板创建演示文稿
+func TestNewFromTemplate(t *testing.T) {
+	data := buildTemplate(t)
+	pres, err := NewFromTemplateReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewFromTemplateReader() 失败: %v", err)
+	}
+
+	if pres.SlideCount() != 0 {
+		t.Errorf("模板的示例幻灯片不应保留，实际有 %d 张", pres.SlideCount())
+	}
+	if pres.title != "" {
+		t.Errorf("不应沿用模板标题，实际为 %q", pres.title)
+	}
+
+	names := pres.LayoutNames()
+	if len(names) != 4 || names[0] != "空白" || names[2] != "Title and Content" {
+		t.Errorf("布局名称不正确: %v", names)
+	}
+
+	pres.AddSlideWithLayout("Title and Content").AddText("内容", TextOptions{})
+	pres.AddSlide()
+	pres.AddSlide().SetLayout(LayoutTitleContent)
+
+	out, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, out)
+	checkWellFormed(t, parts)
+
+	expected := map[string]string{
+		"ppt/slides/_rels/slide1.xml.rels": "../slideLayouts/slideLayout3.xml",
+		"ppt/slides/_rels/slide2.xml.rels": "../slideLayouts/slideLayout1.xml",
+		"ppt/slides/_rels/slide3.xml.rels": "../slideLayouts/slideLayout3.xml",
+	}
+	for name, target := range expected {
+		if !strings.Contains(string(parts[name]), `Target="`+target+`"`) {
+			t.Errorf("%s 应该使用布局 %s: %s", name, target, parts[name])
+		}
+	}
+
+	if _, ok := parts["ppt/media/image1.png"]; ok {
+		t.Error("示例幻灯片的图片不应保留")
+	}
+	if _, ok := parts["ppt/slideLayouts/slideLayout3.xml"]; !ok {
+		t.Error("模板布局丢失")
+	}
+
+	// 找不到布局名称时使用默认布局，保存时返回错误
+	if slide := pres.AddSlideWithLayout("不存在的布局"); slide.layoutTarget != "" {
+		t.Errorf("找不到布局名称时应该使用默认布局，实际为 %s", slide.layoutTarget)
+	}
+	if _, err := pres.ToBytes(); err == nil || !strings.Contains(err.Error(), "不存在的布局") {
+		t.Errorf("找不到布局名称时 ToBytes() 应该返回错误，实际为 %v", err)
+	}
+}
+
+// TestTemplatePlaceholderPosition 测试模板布局中没有右栏占位符时显式指定位置
+func TestTemplatePlaceholderPosition(t *testing.T) {
+	data := buildTemplate(t)
+	pres, err := NewFromTemplateReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewFromTemplateReader() 失败: %v", err)
+	}
+
+	single := pres.AddSlideWithLayout("Title and Content").SetColumn(1, "右栏")
+	if xmlStr := single.generateSlide(single.collectLinks()); !strings.Contains(xmlStr, `<p:ph idx="2"/></p:nvPr></p:nvSpPr><p:spPr><a:xfrm>`) {
+		t.Errorf("布局中没有右栏占位符时应该指定位置: %s", xmlStr)
+	}
+	two := pres.AddSlide().SetLayout(LayoutTwoContent).SetColumn(1, "右栏")
+	if xmlStr := two.generateSlide(two.collectLinks()); !strings.Contains(xmlStr, `<p:ph sz="half" idx="2"/></p:nvPr></p:nvSpPr><p:spPr/>`) {
+		t.Errorf("布局中有右栏占位符时应该继承位置: %s", xmlStr)
+	}
+}
+
+// TestNewFromTemplateSections 测试模板的节和自定义放映不引用已删除的幻灯片
+func TestNewFromTemplateSections(t *testing.T) {
+	pres := New()
+	for range 3 {
+		pres.AddSlide()
+	}
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	parts["ppt/presentation.xml"] = []byte(strings.Replace(string(parts["ppt/presentation.xml"]), `</p:presentation>`,
+		`<p:custShowLst><p:custShow name="精简" id="0"><p:sldLst><p:sldId r:id="rId3"/></p:sldLst></p:custShow></p:custShowLst>`+
+			`<p:extLst><p:ext uri="{521415D9-36F7-43E2-AB2F-B90AF26B5E84}"><p14:sectionLst xmlns:p14="http://schemas.microsoft.com/office/powerpoint/2010/main">`+
+			`<p14:section name="默认节" id="{6A1B2C3D-0000-4000-8000-000000000001}"><p14:sldIdLst><p14:sldId id="256"/><p14:sldId id="257"/><p14:sldId id="258"/></p14:sldIdLst></p14:section>`+
+			`</p14:sectionLst></p:ext></p:extLst></p:presentation>`, 1))
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, _ := zipWriter.Create(name)
+		w.Write(content)
+	}
+	zipWriter.Close()
+
+	tmpl, err := NewFromTemplateReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewFromTemplateReader() 失败: %v", err)
+	}
+	tmpl.AddSlide()
+	out, err := tmpl.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	written := readZipParts(t, out)
+	checkWellFormed(t, written)
+
+	presXML := string(written["ppt/presentation.xml"])
+	for _, s := range []string{"p14:sectionLst", "p14:sldId", "p:custShow"} {
+		if strings.Contains(presXML, s) {
+			t.Errorf("presentation.xml 不应该保留 %s: %s", s, presXML)
+		}
+	}
+	if !strings.Contains(presXML, `<p:sldIdLst><p:sldId id="256"`) {
+		t.Errorf("新幻灯片应该在幻灯片列表中: %s", presXML)
+	}
+
+	// 打开的文件保留仍存在的幻灯片
+	opened, err := OpenReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	out, err = opened.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	presXML = string(readZipParts(t, out)["ppt/presentation.xml"])
+	if strings.Count(presXML, "<p14:sldId ") != 3 || !strings.Contains(presXML, `<p:sldId r:id="rId3"/>`) {
+		t.Errorf("打开的文件应该保留节和自定义放映: %s", presXML)
+	}
+}
+
+// TestSetLayoutNameWithoutTemplate 测试非模板文稿按内置布局名称设置布局
+func TestSetLayoutNameWithoutTemplate(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlideWithLayout("两栏内容")
+	if slide == nil || pres.SlideCount() != 1 {
+		t.Fatal("AddSlideWithLayout() 应该添加幻灯片")
+	}
+	if slide.layout != LayoutTwoContent {
+		t.Errorf("布局应该为 %s，实际为 %s", LayoutTwoContent, slide.layout)
+	}
+	if pres.AddSlideWithLayout("Title and Content").layout != LayoutBlank {
+		t.Error("找不到布局名称时应该保持默认布局")
+	}
+	if _, err := pres.ToBytes(); err == nil {
+		t.Error("找不到布局名称时 ToBytes() 应该返回错误")
+	}
+	if len(pres.LayoutNames()) != 4 {
+		t.Errorf("应该有 4 个内置布局，实际为 %v", pres.LayoutNames())
+	}
+}
@@ -0,0 +1,83 @@
+package genppt
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestAddTextNewlines 测试文本中的换行拆分为段落
+func TestAddTextNewlines(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide()
+	slide.AddText("第一行\n\n第三行", TextOptions{FontSize: 20, Align: AlignCenter})
+
+	xmlStr := slide.generateSlide(slide.collectLinks())
+	if strings.Count(xmlStr, "<a:p>") != 3 {
+		t.Errorf("应该生成 3 个段落: %s", xmlStr)
+	}
+	if strings.Count(xmlStr, `<a:pPr algn="ctr">`) != 3 {
+		t.Error("每个段落都应该沿用对齐方式")
+	}
+	if !strings.Contains(xmlStr, `<a:endParaRPr lang="zh-CN" sz="2000">`) {
+		t.Error("空段落应该保留字号")
+	}
+}
+
+// TestAddRichText 测试富文本段落和文本运行
+func TestAddRichText(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide()
+	paragraphs := []Paragraph{
+		{
+			Runs: []TextRun{
+				{Text: "重要", Bold: Bool(true), FontColor: "C00000"},
+				{Text: "：请注意"},
+			},
+			Align:      AlignCenter,
+			SpaceAfter: 6,
+		},
+		{
+			Runs:        []TextRun{{Text: "二级要点", FontSize: 14, Italic: Bool(true), Underline: Bool(true), Strike: true, FontFace: "Arial"}},
+			Level:       1,
+			SpaceBefore: 12,
+		},
+		{
+			Runs: []TextRun{{Text: "上一行\n下一行"}},
+		},
+	}
+	slide.AddRichText(paragraphs, TextOptions{X: 1, Y: 1, Width: 6, Height: 3, FontSize: 20})
+
+	xmlStr := slide.generateSlide(slide.collectLinks())
+	expected := []string{
+		`<a:pPr algn="ctr"><a:spcAft><a:spcPts val="600"/></a:spcAft></a:pPr>`,
+		`<a:rPr lang="zh-CN" sz="2000" b="1"><a:solidFill><a:srgbClr val="C00000"/></a:solidFill>`,
+		`<a:rPr lang="zh-CN" sz="2000"><a:solidFill><a:srgbClr val="000000"/></a:solidFill>`,
+		`<a:pPr marL="457200" lvl="1" algn="l"><a:spcBef><a:spcPts val="1200"/></a:spcBef></a:pPr>`,
+		`sz="1400" i="1" u="sng" strike="sngStrike"`,
+		`<a:latin typeface="Arial"/>`,
+		`<a:t>上一行</a:t></a:r><a:br>`,
+	}
+	for _, s := range expected {
+		if !strings.Contains(xmlStr, s) {
+			t.Errorf("富文本XML应该包含 %s", s)
+		}
+	}
+	if strings.Count(xmlStr, "<a:p>") != 3 {
+		t.Error("应该生成 3 个段落")
+	}
+
+	// 文本运行可以取消 TextOptions 的粗体和斜体
+	bold := pres.AddSlide()
+	bold.AddRichText([]Paragraph{{Runs: []TextRun{{Text: "标题"}, {Text: "说明", Bold: Bool(false), Italic: Bool(false)}}}}, TextOptions{Bold: true, Italic: true})
+	xmlStr = bold.generateSlide(bold.collectLinks())
+	if !strings.Contains(xmlStr, `b="1" i="1"><a:solidFill>`) || !strings.Contains(xmlStr, `b="0" i="0"><a:solidFill>`) {
+		t.Errorf("未设置的文本运行应该沿用粗体，设为 Bool(false) 时取消: %s", xmlStr)
+	}
+
+	if n := pres.ReplaceText("要点", "事项"); n != 1 {
+		t.Errorf("应该替换 1 处，实际替换 %d 处", n)
+	}
+	if paragraphs[1].Runs[0].Text != "二级要点" {
+		t.Error("替换文本不应修改调用方的段落")
+	}
+}
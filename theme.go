@@ -0,0 +1,184 @@
+package genppt
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Theme 演示文稿主题（配色方案和字体方案）
+// 颜色为十六进制字符串，未设置的字段使用 Office 默认主题的值
+type Theme struct {
+	Name              string     // 主题名称
+	Dark1             string     // 深色1（dk1，默认文本色）
+	Light1            string     // 浅色1（lt1，默认背景色）
+	Dark2             string     // 深色2（dk2）
+	Light2            string     // 浅色2（lt2）
+	Accent1           string     // 强调色1
+	Accent2           string     // 强调色2
+	Accent3           string     // 强调色3
+	Accent4           string     // 强调色4
+	Accent5           string     // 强调色5
+	Accent6           string     // 强调色6
+	Hyperlink         string     // 超链接颜色
+	FollowedHyperlink string     // 已访问超链接颜色
+	MajorFont         ThemeFonts // 标题字体
+	MinorFont         ThemeFonts // 正文字体
+}
+
+// ThemeFonts 主题字体，按文种分别设置
+type ThemeFonts struct {
+	Latin         string            // 西文字体
+	EastAsian     string            // 东亚字体
+	ComplexScript string            // 复杂文种字体
+	Scripts       map[string]string // 指定文种的字体，如 "Hans": "等线"
+}
+
+// DefaultTheme 返回 Office 默认主题
+func DefaultTheme() Theme {
+	return Theme{
+		Name:              "Office",
+		Dark1:             "000000",
+		Light1:            "FFFFFF",
+		Dark2:             "44546A",
+		Light2:            "E7E6E6",
+		Accent1:           "4472C4",
+		Accent2:           "ED7D31",
+		Accent3:           "A5A5A5",
+		Accent4:           "FFC000",
+		Accent5:           "5B9BD5",
+		Accent6:           "70AD47",
+		Hyperlink:         "0563C1",
+		FollowedHyperlink: "954F72",
+		MajorFont: ThemeFonts{
+			Latin: "Calibri Light",
+			Scripts: map[string]string{
+				"Jpan": "游ゴシック Light",
+				"Hang": "맑은 고딕",
+				"Hans": "等线 Light",
+				"Hant": "新細明體",
+			},
+		},
+		MinorFont: ThemeFonts{
+			Latin: "Calibri",
+			Scripts: map[string]string{
+				"Jpan": "游ゴシック",
+				"Hang": "맑은 고딕",
+				"Hans": "等线",
+				"Hant": "新細明體",
+			},
+		},
+	}
+}
+
+// SetTheme 设置演示文稿主题，未设置的字段使用默认值
+// 打开的现有文件会替换其主题中的配色和字体方案
+func (p *Presentation) SetTheme(theme Theme) *Presentation {
+	def := DefaultTheme()
+	theme.Name = defaultIfEmpty(theme.Name, def.Name)
+	theme.Dark1 = defaultIfEmpty(theme.Dark1, def.Dark1)
+	theme.Light1 = defaultIfEmpty(theme.Light1, def.Light1)
+	theme.Dark2 = defaultIfEmpty(theme.Dark2, def.Dark2)
+	theme.Light2 = defaultIfEmpty(theme.Light2, def.Light2)
+	theme.Accent1 = defaultIfEmpty(theme.Accent1, def.Accent1)
+	theme.Accent2 = defaultIfEmpty(theme.Accent2, def.Accent2)
+	theme.Accent3 = defaultIfEmpty(theme.Accent3, def.Accent3)
+	theme.Accent4 = defaultIfEmpty(theme.Accent4, def.Accent4)
+	theme.Accent5 = defaultIfEmpty(theme.Accent5, def.Accent5)
+	theme.Accent6 = defaultIfEmpty(theme.Accent6, def.Accent6)
+	theme.Hyperlink = defaultIfEmpty(theme.Hyperlink, def.Hyperlink)
+	theme.FollowedHyperlink = defaultIfEmpty(theme.FollowedHyperlink, def.FollowedHyperlink)
+	if theme.MajorFont.Latin == "" && theme.MajorFont.EastAsian == "" && len(theme.MajorFont.Scripts) == 0 {
+		theme.MajorFont = def.MajorFont
+	}
+	if theme.MinorFont.Latin == "" && theme.MinorFont.EastAsian == "" && len(theme.MinorFont.Scripts) == 0 {
+		theme.MinorFont = def.MinorFont
+	}
+	p.theme = &theme
+	return p
+}
+
+// themeColors 可以在颜色选项中引用的主题颜色名
+var themeColors = map[string]string{
+	"dk1":      "dk1",
+	"lt1":      "lt1",
+	"dk2":      "dk2",
+	"lt2":      "lt2",
+	"tx1":      "tx1",
+	"bg1":      "bg1",
+	"tx2":      "tx2",
+	"bg2":      "bg2",
+	"accent1":  "accent1",
+	"accent2":  "accent2",
+	"accent3":  "accent3",
+	"accent4":  "accent4",
+	"accent5":  "accent5",
+	"accent6":  "accent6",
+	"hlink":    "hlink",
+	"folhlink": "folHlink",
+}
+
+// themeColorName 返回主题颜色引用对应的 schemeClr 值，不是主题颜色时返回空字符串
+func themeColorName(color string) string {
+	return themeColors[strings.ToLower(color)]
+}
+
+// themeColorShade 解析带亮度的主题颜色引用，如 "accent1-75" 为 accent1 的75%亮度（深色25%）
+// 返回 schemeClr 值和亮度百分比，不是带亮度的主题颜色时返回空字符串
+func themeColorShade(color string) (string, int) {
+	i := strings.LastIndex(color, "-")
+	if i < 0 {
+		return "", 0
+	}
+	name := themeColorName(color[:i])
+	lum, err := strconv.Atoi(color[i+1:])
+	if name == "" || err != nil || lum <= 0 || lum >= 100 {
+		return "", 0
+	}
+	return name, lum
+}
+
+// colorElement 生成颜色元素：主题颜色（如 "accent1"、"accent1-75"）生成 a:schemeClr，其他生成 a:srgbClr
+// mods 为颜色变换子元素（如 a:alpha），可为空
+func colorElement(color, mods string) string {
+	var sb strings.Builder
+	tag := "a:srgbClr"
+	val := ParseColor(color)
+	if name := themeColorName(color); name != "" {
+		tag = "a:schemeClr"
+		val = name
+	} else if name, lum := themeColorShade(color); name != "" {
+		tag = "a:schemeClr"
+		val = name
+		mods = `<a:lumMod val="` + itoa(lum*1000) + `"/>` + mods
+	}
+	sb.WriteString(`<`)
+	sb.WriteString(tag)
+	sb.WriteString(` val="`)
+	sb.WriteString(val)
+	if mods == "" {
+		sb.WriteString(`"/>`)
+		return sb.String()
+	}
+	sb.WriteString(`">`)
+	sb.WriteString(mods)
+	sb.WriteString(`</`)
+	sb.WriteString(tag)
+	sb.WriteString(`>`)
+	return sb.String()
+}
+
+// solidFill 生成纯色填充 a:solidFill
+func solidFill(color string) string {
+	return `<a:solidFill>` + colorElement(color, "") + `</a:solidFill>`
+}
+
+// fontTypeface 返回字体在指定文种（lt/ea/cs）下的 typeface 值
+// "+mj"/"+mn" 开头的主题字体引用按文种展开，如 "+mj" -> "+mj-ea"
+func fontTypeface(face, script string) string {
+	for _, prefix := range []string{"+mj", "+mn"} {
+		if face == prefix || strings.HasPrefix(face, prefix+"-") {
+			return prefix + "-" + script
+		}
+	}
+	return face
+}
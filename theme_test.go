@@ -0,0 +1,141 @@
+package genppt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestSetTheme 测试自定义主题
+func TestSetTheme(t *testing.T) {
+	pres := New()
+	pres.SetTheme(Theme{
+		Name:    "品牌",
+		Accent1: "#C00000",
+		Dark2:   "1F1F1F",
+		MajorFont: ThemeFonts{
+			Latin:     "Georgia",
+			EastAsian: "黑体",
+			Scripts:   map[string]string{"Hans": "黑体", "Hant": "微軟正黑體"},
+		},
+	})
+	pres.AddSlide().AddText("主题", TextOptions{FontColor: "accent1", FontFace: "+mj"})
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+	checkWellFormed(t, parts)
+
+	theme := string(parts["ppt/theme/theme1.xml"])
+	for _, s := range []string{
+		`<a:clrScheme name="品牌">`,
+		`<a:accent1><a:srgbClr val="C00000"/></a:accent1>`,
+		`<a:dk2><a:srgbClr val="1F1F1F"/></a:dk2>`,
+		`<a:accent2><a:srgbClr val="ED7D31"/></a:accent2>`,
+		`<a:latin typeface="Georgia"/>`,
+		`<a:font script="Hans" typeface="黑体"/>`,
+		`<a:latin typeface="Calibri"/>`,
+	} {
+		if !strings.Contains(theme, s) {
+			t.Errorf("theme1.xml 应该包含 %s", s)
+		}
+	}
+	if strings.Index(theme, `script="Hans"`) > strings.Index(theme, `script="Hant"`) {
+		t.Error("文种字体应该按名称排序")
+	}
+
+	slide := string(parts["ppt/slides/slide1.xml"])
+	if !strings.Contains(slide, `<a:solidFill><a:schemeClr val="accent1"/></a:solidFill>`) {
+		t.Error("主题颜色应该生成 a:schemeClr")
+	}
+	if !strings.Contains(slide, `<a:latin typeface="+mj-lt"/><a:ea typeface="+mj-ea"/>`) {
+		t.Error("主题字体引用应该按文种展开")
+	}
+}
+
+// TestThemeColorReferences 测试颜色选项引用主题颜色
+func TestThemeColorReferences(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide()
+	slide.AddShape(ShapeRect, ShapeOptions{Fill: "Accent2", LineColor: "dk1", Transparency: 50})
+	slide.AddShape(ShapeRect, ShapeOptions{Fill: "FF0000"})
+
+	xmlStr := slide.generateSlide(slide.collectLinks())
+	if !strings.Contains(xmlStr, `<a:schemeClr val="accent2"><a:alpha val="50000"/></a:schemeClr>`) {
+		t.Error("透明的主题颜色填充不正确")
+	}
+	if !strings.Contains(xmlStr, `<a:schemeClr val="dk1"/>`) {
+		t.Error("边框的主题颜色不正确")
+	}
+	if !strings.Contains(xmlStr, `<a:srgbClr val="FF0000"/>`) {
+		t.Error("十六进制颜色应该保持 a:srgbClr")
+	}
+	if !strings.Contains(colorElement("Accent6-50", `<a:alpha val="50000"/>`), `<a:schemeClr val="accent6"><a:lumMod val="50000"/><a:alpha val="50000"/></a:schemeClr>`) {
+		t.Error("带亮度的主题颜色应该生成 a:lumMod")
+	}
+}
+
+// TestThemeDefaultColors 测试形状和图表的默认颜色引用主题，切换主题后随之改变
+func TestThemeDefaultColors(t *testing.T) {
+	pres := New()
+	slide := pres.AddSlide()
+	slide.AddShape(ShapeRect, ShapeOptions{})
+	slide.AddShapeWithText(ShapeRoundRect, "文本", ShapeOptions{})
+	slide.AddBarChart("销售", []string{"A", "B"}, map[string][]float64{"S1": {1, 2}, "S2": {3, 4}}, DefaultChartOptions())
+	slide.AddPieChart("占比", []string{"A", "B", "C"}, []float64{1, 2, 3}, DefaultChartOptions())
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, data)
+
+	slideXML := string(parts["ppt/slides/slide1.xml"])
+	fill := `<a:solidFill><a:schemeClr val="accent1"/></a:solidFill><a:ln w="12700"><a:solidFill><a:schemeClr val="accent1"><a:lumMod val="75000"/></a:schemeClr></a:solidFill></a:ln>`
+	if strings.Count(slideXML, fill) != 2 {
+		t.Error("形状的默认填充和边框应该引用 accent1")
+	}
+	if strings.Contains(slideXML, "4472C4") || strings.Contains(slideXML, "2F5496") {
+		t.Error("形状的默认颜色不应该是固定的十六进制颜色")
+	}
+
+	bar := string(parts["ppt/charts/chart1.xml"])
+	for _, accent := range []string{"accent1", "accent2"} {
+		if !strings.Contains(bar, `<a:solidFill><a:schemeClr val="`+accent+`"/></a:solidFill>`) {
+			t.Errorf("柱状图的系列颜色应该引用 %s", accent)
+		}
+	}
+	pie := string(parts["ppt/charts/chart2.xml"])
+	if !strings.Contains(pie, `<c:dPt><c:idx val="2"/><c:spPr><a:solidFill><a:schemeClr val="accent3"/></a:solidFill>`) {
+		t.Error("饼图的扇区颜色应该依次引用主题强调色")
+	}
+	if strings.Contains(bar+pie, "<a:srgbClr val=\"4472C4\"/>") {
+		t.Error("图表的默认颜色不应该是固定的十六进制颜色")
+	}
+}
+
+// TestSetThemeOnOpenedDeck 测试替换打开文件的主题
+func TestSetThemeOnOpenedDeck(t *testing.T) {
+	data := buildSampleDeck(t)
+	pres, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	pres.SetTheme(Theme{Accent1: "112233"})
+
+	out, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	parts := readZipParts(t, out)
+	checkWellFormed(t, parts)
+	theme := string(parts["ppt/theme/theme1.xml"])
+	if !strings.Contains(theme, `<a:accent1><a:srgbClr val="112233"/></a:accent1>`) {
+		t.Error("打开文件的主题配色没有替换")
+	}
+	if strings.Count(theme, "<a:clrScheme ") != 1 || !strings.Contains(theme, "<a:fmtScheme") {
+		t.Error("主题的其余部分应该保留")
+	}
+}
@@ -0,0 +1,138 @@
+package genppt
+
+import (
+	"strings"
+)
+
+// TransitionOptions 幻灯片切换选项
+type TransitionOptions struct {
+	Type         TransitionType      // 切换效果
+	Direction    TransitionDirection // 方向，为空时使用各效果的默认方向
+	Vertical     bool                // 垂直分割（仅用于 TransitionSplit）
+	Duration     float64             // 持续时间（秒），0为默认
+	AdvanceAfter float64             // 自动切换到下一张的时间（秒），0表示不自动切换
+	NoClick      bool                // 禁止单击切换（配合 AdvanceAfter 用于自动播放）
+}
+
+// SetTransition 设置幻灯片切换效果
+func (s *Slide) SetTransition(opts TransitionOptions) *Slide {
+	s.transition = &opts
+	return s
+}
+
+// SetTransition 设置默认的幻灯片切换效果，未单独设置切换效果的幻灯片使用
+func (p *Presentation) SetTransition(opts TransitionOptions) *Presentation {
+	p.transition = &opts
+	return p
+}
+
+// generateTransition 生成幻灯片的切换效果XML
+// 依次使用幻灯片自身的设置、打开文件中原有的切换效果、演示文稿的默认设置
+func (s *Slide) generateTransition() string {
+	if s.transition != nil {
+		return s.transition.generateXML()
+	}
+	if s.rawTransition != "" {
+		return s.rawTransition
+	}
+	if s.presentation.transition != nil {
+		return s.presentation.transition.generateXML()
+	}
+	return ""
+}
+
+// generateXML 生成 p:transition，设置持续时间或平滑效果时使用 mc:AlternateContent 兼容旧版本
+func (t *TransitionOptions) generateXML() string {
+	if t.Type == TransitionMorph || t.Duration > 0 {
+		var sb strings.Builder
+		sb.WriteString(`<mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006">`)
+		if t.Type == TransitionMorph {
+			sb.WriteString(`<mc:Choice xmlns:p14="http://schemas.microsoft.com/office/powerpoint/2010/main" xmlns:p159="http://schemas.microsoft.com/office/powerpoint/2015/09/main" Requires="p159">`)
+		} else {
+			sb.WriteString(`<mc:Choice xmlns:p14="http://schemas.microsoft.com/office/powerpoint/2010/main" Requires="p14">`)
+		}
+		sb.WriteString(t.transitionElement(true))
+		sb.WriteString(`</mc:Choice>`)
+		sb.WriteString(`<mc:Fallback>`)
+		sb.WriteString(t.transitionElement(false))
+		sb.WriteString(`</mc:Fallback>`)
+		sb.WriteString(`</mc:AlternateContent>`)
+		return sb.String()
+	}
+	return t.transitionElement(false)
+}
+
+// transitionElement 生成 p:transition 元素，extended 为 true 时写入 p14 持续时间和 p159 平滑效果
+func (t *TransitionOptions) transitionElement(extended bool) string {
+	var sb strings.Builder
+	sb.WriteString(`<p:transition`)
+	if t.Duration > 0 {
+		// 旧版本只支持三档速度
+		switch {
+		case t.Duration <= 0.5:
+			sb.WriteString(` spd="fast"`)
+		case t.Duration <= 0.75:
+			sb.WriteString(` spd="med"`)
+		default:
+			sb.WriteString(` spd="slow"`)
+		}
+		if extended {
+			sb.WriteString(` p14:dur="`)
+			sb.WriteString(itoa(int(t.Duration * 1000)))
+			sb.WriteString(`"`)
+		}
+	}
+	if t.NoClick {
+		sb.WriteString(` advClick="0"`)
+	}
+	if t.AdvanceAfter > 0 {
+		sb.WriteString(` advTm="`)
+		sb.WriteString(itoa(int(t.AdvanceAfter * 1000)))
+		sb.WriteString(`"`)
+	}
+
+	effect := t.effectElement(extended)
+	if effect == "" {
+		sb.WriteString(`/>`)
+		return sb.String()
+	}
+	sb.WriteString(`>`)
+	sb.WriteString(effect)
+	sb.WriteString(`</p:transition>`)
+	return sb.String()
+}
+
+// effectElement 生成切换效果子元素
+func (t *TransitionOptions) effectElement(extended bool) string {
+	dir := t.Direction
+	switch t.Type {
+	case TransitionFade:
+		return `<p:fade/>`
+	case TransitionMorph:
+		if extended {
+			return `<p159:morph option="byObject"/>`
+		}
+		return `<p:fade/>`
+	case TransitionPush, TransitionWipe, TransitionCover:
+		// 只支持上下左右四个方向
+		if dir != DirectionLeft && dir != DirectionRight && dir != DirectionUp && dir != DirectionDown {
+			dir = DirectionLeft
+		}
+		return `<p:` + string(t.Type) + ` dir="` + string(dir) + `"/>`
+	case TransitionSplit:
+		if dir != DirectionIn {
+			dir = DirectionOut
+		}
+		orient := "horz"
+		if t.Vertical {
+			orient = "vert"
+		}
+		return `<p:split orient="` + orient + `" dir="` + string(dir) + `"/>`
+	case TransitionZoom:
+		if dir != DirectionOut {
+			dir = DirectionIn
+		}
+		return `<p:zoom dir="` + string(dir) + `"/>`
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package genppt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestSlideTransition 测试幻灯片切换效果
+func TestSlideTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     TransitionOptions
+		contains []string
+	}{
+		{"淡入淡出", TransitionOptions{Type: TransitionFade}, []string{`<p:transition><p:fade/></p:transition>`}},
+		{"推入", TransitionOptions{Type: TransitionPush, Direction: DirectionUp}, []string{`<p:push dir="u"/>`}},
+		{"擦除默认方向", TransitionOptions{Type: TransitionWipe, Direction: DirectionIn}, []string{`<p:wipe dir="l"/>`}},
+		{"分割", TransitionOptions{Type: TransitionSplit, Vertical: true, Direction: DirectionIn}, []string{`<p:split orient="vert" dir="in"/>`}},
+		{"覆盖", TransitionOptions{Type: TransitionCover, Direction: DirectionRight}, []string{`<p:cover dir="r"/>`}},
+		{"缩放", TransitionOptions{Type: TransitionZoom, Direction: DirectionOut}, []string{`<p:zoom dir="out"/>`}},
+		{"自动切换", TransitionOptions{Type: TransitionFade, AdvanceAfter: 5, NoClick: true}, []string{`<p:transition advClick="0" advTm="5000"><p:fade/></p:transition>`}},
+		{"持续时间", TransitionOptions{Type: TransitionFade, Duration: 1.5}, []string{
+			`Requires="p14"><p:transition spd="slow" p14:dur="1500"><p:fade/></p:transition></mc:Choice>`,
+			`<mc:Fallback><p:transition spd="slow"><p:fade/></p:transition></mc:Fallback>`,
+		}},
+		{"平滑", TransitionOptions{Type: TransitionMorph, Duration: 2}, []string{
+			`Requires="p159"><p:transition spd="slow" p14:dur="2000"><p159:morph option="byObject"/></p:transition>`,
+			`<mc:Fallback><p:transition spd="slow"><p:fade/></p:transition></mc:Fallback>`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pres := New()
+			slide := pres.AddSlide().SetTransition(tt.opts)
+			xmlStr := slide.generateSlide(slide.collectLinks())
+			for _, s := range tt.contains {
+				if !strings.Contains(xmlStr, s) {
+					t.Errorf("应该包含 %s", s)
+				}
+			}
+			if strings.Index(xmlStr, "</p:clrMapOvr>") > strings.Index(xmlStr, "<p:transition") {
+				t.Error("切换效果应该位于 clrMapOvr 之后")
+			}
+		})
+	}
+}
+
+// TestDefaultTransition 测试演示文稿的默认切换效果
+func TestDefaultTransition(t *testing.T) {
+	pres := New()
+	pres.SetTransition(TransitionOptions{Type: TransitionFade, AdvanceAfter: 3})
+	first := pres.AddSlide()
+	second := pres.AddSlide().SetTransition(TransitionOptions{Type: TransitionPush})
+
+	if !strings.Contains(first.generateSlide(first.collectLinks()), `<p:transition advTm="3000"><p:fade/></p:transition>`) {
+		t.Error("未设置的幻灯片应该使用默认切换效果")
+	}
+	if !strings.Contains(second.generateSlide(second.collectLinks()), `<p:push dir="l"/>`) {
+		t.Error("幻灯片自身的切换效果应该优先")
+	}
+
+	data, err := pres.ToBytes()
+	if err != nil {
+		t.Fatalf("ToBytes() 失败: %v", err)
+	}
+	checkWellFormed(t, readZipParts(t, data))
+
+	// 打开的文件保留原有切换效果
+	opened, err := OpenReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenReader() 失败: %v", err)
+	}
+	openedSlide := opened.GetSlide(1)
+	if !strings.Contains(openedSlide.generateSlide(openedSlide.collectLinks()), `<p:push dir="l"/>`) {
+		t.Error("打开文件后切换效果丢失")
+	}
+}
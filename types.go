@@ -21,54 +21,112 @@ const (
 
 // TextOptions 文本选项
 type TextOptions struct {
-	X           float64       // X坐标（英寸）
-	Y           float64       // Y坐标（英寸）
-	Width       float64       // 宽度（英寸）
-	Height      float64       // 高度（英寸）
-	FontFace    string        // 字体名称
-	FontSize    float64       // 字号（磅）
-	FontColor   string        // 字体颜色（十六进制，如"#FF0000"或"FF0000"）
-	Bold        bool          // 是否粗体
-	Italic      bool          // 是否斜体
-	Underline   bool          // 是否下划线
-	Align       Align         // 水平对齐
-	VAlign      VerticalAlign // 垂直对齐
-	LineSpacing float64       // 行间距（倍数）
-	CharSpacing float64       // 字符间距（磅），0为默认
-	Rotate      float64       // 旋转角度（度）
-	Margin      float64       // 内边距（英寸）
-	Fill        string        // 文本框背景色（十六进制），为空则无填充
+	X           float64           // X坐标（英寸）
+	Y           float64           // Y坐标（英寸）
+	Width       float64           // 宽度（英寸）
+	Height      float64           // 高度（英寸）
+	FontFace    string            // 字体名称，"+mj"/"+mn" 表示主题的标题/正文字体
+	FontSize    float64           // 字号（磅）
+	FontColor   string            // 字体颜色（十六进制，如"#FF0000"或"FF0000"，也可为主题颜色如"accent1"）
+	Bold        bool              // 是否粗体
+	Italic      bool              // 是否斜体
+	Underline   bool              // 是否下划线
+	Align       Align             // 水平对齐
+	VAlign      VerticalAlign     // 垂直对齐
+	LineSpacing float64           // 行间距（倍数）
+	CharSpacing float64           // 字符间距（磅），0为默认
+	Rotate      float64           // 旋转角度（度）
+	Margin      float64           // 内边距（英寸）
+	Fill        string            // 文本框背景色（十六进制），为空则无填充
+	Link        *Hyperlink        // 文字的点击链接
+	Animation   *AnimationOptions // 动画
+}
+
+// Hyperlink 超链接或点击动作，URL、Email、Slide、Action 只需设置其一
+type Hyperlink struct {
+	URL     string     // 外部链接，如 "https://example.com"
+	Email   string     // 邮件地址，生成 mailto: 链接
+	Subject string     // 邮件主题（仅用于 Email）
+	Slide   int        // 跳转到第N张幻灯片（从1开始）
+	Action  LinkAction // 跳转动作：下一张、上一张、第一张、最后一张
+	Tooltip string     // 鼠标悬停提示
+}
+
+// TextRun 文本运行，段落中格式相同的一段文字
+// 未设置的字体、字号、颜色沿用 TextOptions
+// 只有 TextOptions 中也有的粗体、斜体、下划线为三态（nil 沿用，Bool(true)/Bool(false) 覆盖）；
+// 删除线、上标、下标只作用于本运行，TextOptions 没有对应设置，所以使用 bool
+type TextRun struct {
+	Text      string     // 文本内容，换行符生成段内换行
+	FontFace  string     // 字体名称
+	FontSize  float64    // 字号（磅）
+	FontColor string     // 字体颜色（十六进制或主题颜色）
+	Bold      *bool      // 是否粗体，为空时沿用 TextOptions，Bool(false) 取消粗体
+	Italic    *bool      // 是否斜体，为空时沿用 TextOptions
+	Underline *bool      // 是否下划线，为空时沿用 TextOptions
+	Strike    bool       // 是否删除线
+	Link      *Hyperlink // 点击链接，为空时沿用 TextOptions.Link
+
+	Superscript bool // 上标，如脚注标记
+	Subscript   bool // 下标
+}
+
+// Paragraph 段落
+type Paragraph struct {
+	Runs        []TextRun // 文本运行
+	Align       Align     // 水平对齐，为空时沿用 TextOptions
+	SpaceBefore float64   // 段前间距（磅）
+	SpaceAfter  float64   // 段后间距（磅）
+	LineSpacing float64   // 行间距（倍数），为0时沿用 TextOptions
+	Level       int       // 缩进级别（0-8）
+	Bullet      string    // 项目符号字符，如 "•"，为空时无项目符号
+	Numbered    bool      // 使用编号（1. 2. 3.），优先于 Bullet
 }
 
 // ShapeOptions 形状选项
 type ShapeOptions struct {
-	X            float64     // X坐标（英寸）
-	Y            float64     // Y坐标（英寸）
-	Width        float64     // 宽度（英寸）
-	Height       float64     // 高度（英寸）
-	Fill         string      // 填充颜色（十六进制）
-	LineColor    string      // 边框颜色（十六进制）
-	LineWidth    float64     // 边框宽度（磅）
-	LineStyle    BorderStyle // 边框样式
-	Rotate       float64     // 旋转角度（度）
-	Transparency float64     // 透明度（0-100）
-	Shadow       bool        // 是否有阴影
+	X            float64           // X坐标（英寸）
+	Y            float64           // Y坐标（英寸）
+	Width        float64           // 宽度（英寸）
+	Height       float64           // 高度（英寸）
+	Fill         string            // 填充颜色（十六进制或主题颜色如"accent1"）
+	LineColor    string            // 边框颜色（十六进制或主题颜色），默认为深25%的 accent1
+	LineWidth    float64           // 边框宽度（磅）
+	LineStyle    BorderStyle       // 边框样式
+	Rotate       float64           // 旋转角度（度）
+	Transparency float64           // 透明度（0-100）
+	Shadow       bool              // 是否有阴影
+	Link         *Hyperlink        // 点击形状时的链接
+	Animation    *AnimationOptions // 动画
 }
 
 // TableOptions 表格选项
 type TableOptions struct {
-	X            float64   // X坐标（英寸）
-	Y            float64   // Y坐标（英寸）
-	Width        float64   // 总宽度（英寸）
-	RowHeights   []float64 // 每行高度（英寸），为空则自动
-	ColWidths    []float64 // 每列宽度（英寸），为空则平均分配
-	FontFace     string    // 默认字体
-	FontSize     float64   // 默认字号
-	FontColor    string    // 默认字体颜色
-	Fill         string    // 默认单元格背景色
-	Border       Border    // 边框设置
-	FirstRowBold bool      // 首行是否加粗
-	FirstRowFill string    // 首行背景色
+	X             float64           // X坐标（英寸）
+	Y             float64           // Y坐标（英寸）
+	Width         float64           // 总宽度（英寸）
+	RowHeights    []float64         // 每行高度（英寸），为空则自动
+	ColWidths     []float64         // 每列宽度（英寸），为空则平均分配
+	AutoColWidths bool              // ColWidths 为空时按每列文字的长度分配列宽
+	FontFace      string            // 默认字体
+	FontSize      float64           // 默认字号
+	FontColor     string            // 默认字体颜色
+	Fill          string            // 默认单元格背景色
+	Border        Border            // 边框设置
+	FirstRowBold  bool              // 首行是否加粗
+	FirstRowFill  string            // 首行背景色
+	Animation     *AnimationOptions // 动画
+
+	// Style 表格样式，为空时使用中度样式2-强调1，并按上面的颜色和边框格式化单元格；
+	// 设置后不再添加默认的字体颜色和边框，由样式决定，可以在 PowerPoint 中切换样式
+	Style TableStyle
+	// 以下开关决定样式中哪些部分生效，默认为标题行和镶边行，与 PowerPoint 插入的表格一致
+	HeaderRow     *bool // 标题行：首行使用特殊格式，为空时开启，Bool(false) 关闭
+	TotalRow      bool  // 汇总行：末行使用特殊格式
+	FirstColumn   bool  // 第一列使用特殊格式
+	LastColumn    bool  // 最后一列使用特殊格式
+	BandedRows    *bool // 镶边行：奇偶行交替格式，为空时开启，Bool(false) 关闭
+	BandedColumns bool  // 镶边列：奇偶列交替格式
 }
 
 // Border 边框配置
@@ -80,7 +138,7 @@ type Border struct {
 
 // TableCell 表格单元格
 type TableCell struct {
-	Text      string        // 文本内容
+	Text      string        // 文本内容，换行符拆分为多个段落
 	FontFace  string        // 字体（覆盖表格默认）
 	FontSize  float64       // 字号（覆盖表格默认）
 	FontColor string        // 字体颜色
@@ -91,22 +149,46 @@ type TableCell struct {
 	VAlign    VerticalAlign // 垂直对齐
 	ColSpan   int           // 列合并数
 	RowSpan   int           // 行合并数
+
+	// 单元格边框，为 nil 时使用相邻单元格在同一条边上的设置，再使用 TableOptions.Border
+	// 未设置颜色和宽度时沿用 TableOptions.Border；Style 为 BorderNone 时去掉该边框
+	BorderTop    *Border
+	BorderBottom *Border
+	BorderLeft   *Border
+	BorderRight  *Border
+	DiagonalDown *Border      // 左上到右下的斜线
+	DiagonalUp   *Border      // 左下到右上的斜线
+	Margins      *CellMargins // 内边距，为 nil 或某边为0时该边使用 PowerPoint 默认值（左右0.1英寸，上下0.05英寸）
+
+	// Paragraphs 富文本段落，设置后忽略 Text；未设置的字体、字号、颜色、对齐沿用单元格和表格的设置
+	Paragraphs    []Paragraph
+	TextDirection TextDirection // 文字方向，为空时横排
+}
+
+// CellMargins 单元格内边距（英寸），为0的边使用默认值
+type CellMargins struct {
+	Left   float64
+	Right  float64
+	Top    float64
+	Bottom float64
 }
 
 // ImageOptions 图片选项
 type ImageOptions struct {
-	X               float64 // X坐标（英寸）
-	Y               float64 // Y坐标（英寸）
-	Width           float64 // 宽度（英寸）
-	Height          float64 // 高度（英寸）
-	Path            string  // 本地文件路径
-	Data            []byte  // 图片数据（与Path二选一）
-	AltText         string  // 替代文本
-	Rotate          float64 // 旋转角度（度）
-	Rounding        float64 // 圆角半径（英寸），0为直角
-	CodeBackground  string  // 代码背景色
-	SlideBackground string  // 幻灯片背景色
-	ImageRounding   float64 // 图片圆角（英寸），默认0
+	X               float64           // X坐标（英寸）
+	Y               float64           // Y坐标（英寸）
+	Width           float64           // 宽度（英寸）
+	Height          float64           // 高度（英寸）
+	Path            string            // 本地文件路径
+	Data            []byte            // 图片数据（与Path二选一）
+	AltText         string            // 替代文本
+	Link            *Hyperlink        // 点击图片时的链接
+	Animation       *AnimationOptions // 动画
+	Rotate          float64           // 旋转角度（度）
+	Rounding        float64           // 圆角半径（英寸），0为直角
+	CodeBackground  string            // 代码背景色
+	SlideBackground string            // 幻灯片背景色
+	ImageRounding   float64           // 图片圆角（英寸），默认0
 }
 
 // BackgroundOptions 背景选项
@@ -123,8 +205,9 @@ type slideObject interface {
 
 // textObject 文本对象
 type textObject struct {
-	text    string
-	options TextOptions
+	text       string
+	paragraphs []Paragraph // 富文本段落，为空时按换行拆分 text
+	options    TextOptions
 }
 
 func (t *textObject) getType() string { return "text" }
@@ -155,6 +238,22 @@ type imageObject struct {
 
 func (i *imageObject) getType() string { return "image" }
 
+// rawObject 从现有文件读取、原样保留的形状XML
+type rawObject struct {
+	xml string // 形状树中的原始元素
+}
+
+func (r *rawObject) getType() string { return "raw" }
+
+// placeholderObject 填充布局占位符的文本（位置和样式继承自布局）
+type placeholderObject struct {
+	idx       int               // 0为标题，1为正文/左栏，2为右栏
+	text      string            // 多行文本按换行拆分为段落
+	animation *AnimationOptions // 动画
+}
+
+func (p *placeholderObject) getType() string { return "placeholder" }
+
 // Slide 幻灯片结构
 type Slide struct {
 	presentation *Presentation
@@ -162,7 +261,22 @@ type Slide struct {
 	objects      []slideObject
 	background   *BackgroundOptions
 	notes        string
+	transition   *TransitionOptions
 	number       int // 幻灯片序号
+
+	// 以下字段仅用于从现有文件打开的幻灯片
+	sourceID      int          // 原始 p:sldId 的 id
+	sourceRelID   string       // 原始 presentation.xml.rels 中的关系ID
+	layoutTarget  string       // 布局关系目标（相对于 ppt/slides/）
+	sourceRels    []packageRel // 原样保留的关系（ID已重映射）
+	rawAttrs      string       // 根元素上额外的属性（命名空间声明等）
+	rawBackground string       // 原始背景 p:bg
+	rawTransition string       // 原始切换效果
+	rawTiming     string       // 原始动画时间轴
+	rawExtLst     string       // 原始扩展列表
+	maxShapeID    int          // 原始形状的最大ID
+
+	linkIDs map[*Hyperlink]string // 正在生成的幻灯片中链接对应的关系ID，由 generateSlide 设置
 }
 
 // Presentation 演示文稿结构
@@ -177,6 +291,11 @@ type Presentation struct {
 	slideWidth  int64 // EMU
 	slideHeight int64 // EMU
 	mediaFiles  []mediaFile
+	theme       *Theme             // 自定义主题，nil时使用Office默认主题
+	transition  *TransitionOptions // 默认切换效果
+	pkg         *sourcePackage     // 打开的现有文件，New()创建时为nil
+	tableStyles []customTableStyle // 自定义表格样式
+	err         error              // 添加内容时出现的第一个错误，保存时返回
 }
 
 // mediaFile 媒体文件
@@ -202,6 +321,11 @@ func CMToEMU(cm float64) int64 {
 	return int64(cm * EMUPerCM)
 }
 
+// Bool 返回指向 v 的指针，用于区分未设置和 false 的选项（如 ChartStyle.TitleBold、TextRun.Bold）
+func Bool(v bool) *bool {
+	return &v
+}
+
 // ParseColor 解析颜色字符串，处理#前缀和3位简写
 func ParseColor(color string) string {
 	if color == "" {
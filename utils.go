@@ -141,6 +141,14 @@ func getExtFromPath(path string) string {
 	return strings.ToLower(path[idx+1:])
 }
 
+// boolOr 返回 v 指向的值，为 nil 时返回默认值
+func boolOr(v *bool, defaultVal bool) bool {
+	if v == nil {
+		return defaultVal
+	}
+	return *v
+}
+
 // defaultIfZero 如果值为0则返回默认值
 func defaultIfZero(val, defaultVal float64) float64 {
 	if val == 0 {
@@ -7,16 +7,17 @@ import (
 
 // VideoOptions 视频选项
 type VideoOptions struct {
-	X        float64 // X坐标（英寸）
-	Y        float64 // Y坐标（英寸）
-	Width    float64 // 宽度（英寸）
-	Height   float64 // 高度（英寸）
-	Path     string  // 本地视频文件路径
-	Data     []byte  // 视频数据（与Path二选一）
-	Poster   []byte  // 封面图片数据（可选）
-	AutoPlay bool    // 是否自动播放
-	Loop     bool    // 是否循环播放
-	Muted    bool    // 是否静音
+	X         float64           // X坐标（英寸）
+	Y         float64           // Y坐标（英寸）
+	Width     float64           // 宽度（英寸）
+	Height    float64           // 高度（英寸）
+	Path      string            // 本地视频文件路径
+	Data      []byte            // 视频数据（与Path二选一）
+	Poster    []byte            // 封面图片数据（可选）
+	AutoPlay  bool              // 是否自动播放
+	Loop      bool              // 是否循环播放
+	Muted     bool              // 是否静音
+	Animation *AnimationOptions // 动画
 }
 
 // videoObject 视频对象
@@ -63,8 +64,8 @@ func (s *Slide) AddVideo(opts VideoOptions) *Slide {
 	}
 
 	// 生成媒体文件关系ID
-	mediaIndex := len(s.presentation.mediaFiles) + 1
-	rID := "rId" + itoa(mediaIndex+300) // 使用较大的rId避免冲突
+	mediaIndex := s.presentation.nextMediaIndex()
+	rID := mediaRelID(mediaIndex)
 	mediaPath := "ppt/media/video" + itoa(mediaIndex) + "." + ext
 
 	// 添加到演示文稿的媒体文件列表
@@ -87,8 +88,8 @@ func (s *Slide) AddVideo(opts VideoOptions) *Slide {
 		if posterExt == "" {
 			posterExt = "png"
 		}
-		posterIndex := len(s.presentation.mediaFiles) + 1
-		posterRID := "rId" + itoa(posterIndex+300)
+		posterIndex := s.presentation.nextMediaIndex()
+		posterRID := mediaRelID(posterIndex)
 		posterPath := "ppt/media/poster" + itoa(posterIndex) + "." + posterExt
 
 		s.presentation.mediaFiles = append(s.presentation.mediaFiles, mediaFile{
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"io"
+	"strings"
 )
 
 // pptxWriter 负责将演示文稿打包为PPTX文件
@@ -18,6 +19,10 @@ func newPptxWriter(pres *Presentation) *pptxWriter {
 
 // write 写入到io.Writer
 func (w *pptxWriter) write(writer io.Writer) error {
+	if w.pres.err != nil {
+		return w.pres.err
+	}
+
 	zipWriter := zip.NewWriter(writer)
 	defer zipWriter.Close()
 
@@ -27,7 +32,11 @@ func (w *pptxWriter) write(writer io.Writer) error {
 	}
 
 	// _rels/.rels
-	if err := w.addFile(zipWriter, "_rels/.rels", generateRootRels()); err != nil {
+	rootRels := generateRootRels()
+	if w.pres.pkg != nil {
+		rootRels = w.pres.pkg.generateRootRels()
+	}
+	if err := w.addFile(zipWriter, "_rels/.rels", rootRels); err != nil {
 		return err
 	}
 
@@ -51,57 +60,111 @@ func (w *pptxWriter) write(writer io.Writer) error {
 		return err
 	}
 
-	// ppt/presProps.xml
-	if err := w.addFile(zipWriter, "ppt/presProps.xml", generatePresProps()); err != nil {
-		return err
-	}
+	if w.pres.pkg != nil {
+		// 打开的文件：母版、布局、主题等部件原样写回
+		for _, name := range w.pres.pkg.partNames {
+			data := w.pres.pkg.parts[name]
+			if w.pres.theme != nil && strings.HasPrefix(name, "ppt/theme/") && strings.HasSuffix(name, ".xml") {
+				data = w.pres.applyTheme(data)
+			}
+			if len(w.pres.tableStyles) > 0 && name == "ppt/tableStyles.xml" {
+				data = w.pres.applyTableStyles(data)
+			}
+			if err := w.addBytes(zipWriter, name, data); err != nil {
+				return err
+			}
+		}
+		// 原文件没有 tableStyles.xml 时新建，用于保存自定义表格样式
+		if w.pres.needsTableStylesPart() {
+			if err := w.addFile(zipWriter, "ppt/tableStyles.xml", w.pres.generateTableStyles()); err != nil {
+				return err
+			}
+		}
+	} else {
+		// ppt/presProps.xml
+		if err := w.addFile(zipWriter, "ppt/presProps.xml", generatePresProps()); err != nil {
+			return err
+		}
 
-	// ppt/viewProps.xml
-	if err := w.addFile(zipWriter, "ppt/viewProps.xml", generateViewProps()); err != nil {
-		return err
-	}
+		// ppt/viewProps.xml
+		if err := w.addFile(zipWriter, "ppt/viewProps.xml", generateViewProps()); err != nil {
+			return err
+		}
 
-	// ppt/tableStyles.xml
-	if err := w.addFile(zipWriter, "ppt/tableStyles.xml", generateTableStyles()); err != nil {
-		return err
-	}
+		// ppt/tableStyles.xml
+		if err := w.addFile(zipWriter, "ppt/tableStyles.xml", w.pres.generateTableStyles()); err != nil {
+			return err
+		}
 
-	// ppt/theme/theme1.xml
-	if err := w.addFile(zipWriter, "ppt/theme/theme1.xml", generateTheme()); err != nil {
-		return err
-	}
+		// ppt/theme/theme1.xml
+		if err := w.addFile(zipWriter, "ppt/theme/theme1.xml", w.pres.generateTheme()); err != nil {
+			return err
+		}
 
-	// ppt/slideMasters/slideMaster1.xml
-	if err := w.addFile(zipWriter, "ppt/slideMasters/slideMaster1.xml", w.pres.generateSlideMaster()); err != nil {
-		return err
-	}
+		// ppt/slideMasters/slideMaster1.xml
+		if err := w.addFile(zipWriter, "ppt/slideMasters/slideMaster1.xml", w.pres.generateSlideMaster()); err != nil {
+			return err
+		}
 
-	// ppt/slideMasters/_rels/slideMaster1.xml.rels
-	if err := w.addFile(zipWriter, "ppt/slideMasters/_rels/slideMaster1.xml.rels", generateSlideMasterRels()); err != nil {
-		return err
-	}
+		// ppt/slideMasters/_rels/slideMaster1.xml.rels
+		if err := w.addFile(zipWriter, "ppt/slideMasters/_rels/slideMaster1.xml.rels", generateSlideMasterRels()); err != nil {
+			return err
+		}
 
-	// ppt/slideLayouts/slideLayout1.xml
-	if err := w.addFile(zipWriter, "ppt/slideLayouts/slideLayout1.xml", w.pres.generateSlideLayout()); err != nil {
-		return err
-	}
+		for i, layout := range builtinLayouts {
+			layoutNum := itoa(i + 1)
 
-	// ppt/slideLayouts/_rels/slideLayout1.xml.rels
-	if err := w.addFile(zipWriter, "ppt/slideLayouts/_rels/slideLayout1.xml.rels", generateSlideLayoutRels()); err != nil {
-		return err
+			// ppt/slideLayouts/slideLayoutN.xml
+			if err := w.addFile(zipWriter, "ppt/slideLayouts/slideLayout"+layoutNum+".xml", w.pres.generateSlideLayout(layout)); err != nil {
+				return err
+			}
+
+			// ppt/slideLayouts/_rels/slideLayoutN.xml.rels
+			if err := w.addFile(zipWriter, "ppt/slideLayouts/_rels/slideLayout"+layoutNum+".xml.rels", generateSlideLayoutRels()); err != nil {
+				return err
+			}
+		}
 	}
 
 	// 幻灯片
 	for i, slide := range w.pres.slides {
 		slideNum := i + 1
+		links := slide.collectLinks()
 
 		// ppt/slides/slideN.xml
-		if err := w.addFile(zipWriter, "ppt/slides/slide"+itoa(slideNum)+".xml", slide.generateSlide()); err != nil {
+		if err := w.addFile(zipWriter, "ppt/slides/slide"+itoa(slideNum)+".xml", slide.generateSlide(links)); err != nil {
 			return err
 		}
 
 		// ppt/slides/_rels/slideN.xml.rels
-		if err := w.addFile(zipWriter, "ppt/slides/_rels/slide"+itoa(slideNum)+".xml.rels", slide.generateSlideRels()); err != nil {
+		if err := w.addFile(zipWriter, "ppt/slides/_rels/slide"+itoa(slideNum)+".xml.rels", slide.generateSlideRels(links)); err != nil {
+			return err
+		}
+
+		if slide.notes == "" {
+			continue
+		}
+
+		// ppt/notesSlides/notesSlideN.xml
+		if err := w.addFile(zipWriter, "ppt/notesSlides/notesSlide"+itoa(slideNum)+".xml", slide.generateNotesSlide()); err != nil {
+			return err
+		}
+
+		// ppt/notesSlides/_rels/notesSlideN.xml.rels
+		if err := w.addFile(zipWriter, "ppt/notesSlides/_rels/notesSlide"+itoa(slideNum)+".xml.rels", slide.generateNotesSlideRels(slideNum)); err != nil {
+			return err
+		}
+	}
+
+	// 备注母版及其主题
+	if w.pres.needsNotesMaster() {
+		if err := w.addFile(zipWriter, w.pres.notesMasterPath(), w.pres.generateNotesMaster()); err != nil {
+			return err
+		}
+		if err := w.addFile(zipWriter, relsPathFor(w.pres.notesMasterPath()), w.pres.generateNotesMasterRels()); err != nil {
+			return err
+		}
+		if err := w.addFile(zipWriter, w.pres.notesThemePath(), w.pres.generateTheme()); err != nil {
 			return err
 		}
 	}
@@ -113,7 +176,7 @@ func (w *pptxWriter) write(writer io.Writer) error {
 		}
 	}
 
-	// 图表文件
+	// 图表文件及其嵌入的工作簿
 	for _, slide := range w.pres.slides {
 		for _, obj := range slide.objects {
 			if chart, ok := obj.(*chartObject); ok {
@@ -121,6 +184,16 @@ func (w *pptxWriter) write(writer io.Writer) error {
 				if err := w.addFile(zipWriter, chartPath, chart.generateChartXML()); err != nil {
 					return err
 				}
+				if err := w.addFile(zipWriter, relsPathFor(chartPath), chart.generateChartRels()); err != nil {
+					return err
+				}
+				workbook, err := generateWorkbook(chart.worksheetRows())
+				if err != nil {
+					return err
+				}
+				if err := w.addBytes(zipWriter, chart.workbookPath(), workbook); err != nil {
+					return err
+				}
 			}
 		}
 	}
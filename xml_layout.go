@@ -4,6 +4,66 @@ import (
 	"strings"
 )
 
+// placeholderSpec 布局中的占位符
+type placeholderSpec struct {
+	phType string  // 占位符类型，空表示内容占位符
+	idx    int     // 占位符序号，标题为0
+	sz     string  // 占位符尺寸（half等）
+	name   string  // 形状名称
+	prompt string  // 提示文本
+	center bool    // 文本居中（标题幻灯片）
+	x, y   float64 // 位置（占幻灯片宽高的比例）
+	w, h   float64 // 尺寸（占幻灯片宽高的比例）
+}
+
+// builtinLayout 生成文稿内置的幻灯片布局
+type builtinLayout struct {
+	layout       SlideLayout
+	layoutType   string // p:sldLayout 的 type 属性
+	name         string
+	placeholders []placeholderSpec
+}
+
+var (
+	// 标题和正文的通用位置
+	titlePlaceholder = placeholderSpec{phType: "title", name: "标题 1", prompt: "单击此处添加标题", x: 0.06875, y: 0.0532, w: 0.8625, h: 0.1933}
+	bodyPlaceholder  = placeholderSpec{idx: 1, name: "内容占位符 2", prompt: "单击此处添加文本", x: 0.06875, y: 0.2662, w: 0.8625, h: 0.6345}
+
+	// masterPlaceholders 母版中的占位符，没有对应布局占位符时幻灯片从这里继承位置
+	masterPlaceholders = []placeholderSpec{
+		titlePlaceholder,
+		{phType: "body", idx: 1, name: "文本占位符 2", prompt: "单击此处添加文本", x: 0.06875, y: 0.2662, w: 0.8625, h: 0.6345},
+	}
+
+	// builtinLayouts 内置布局，顺序即 slideLayoutN 的序号
+	builtinLayouts = []builtinLayout{
+		{layout: LayoutBlank, layoutType: "blank", name: "空白"},
+		{layout: LayoutTitle, layoutType: "title", name: "标题幻灯片", placeholders: []placeholderSpec{
+			{phType: "ctrTitle", name: "标题 1", prompt: "单击此处添加标题", center: true, x: 0.125, y: 0.1637, w: 0.75, h: 0.3481},
+			{phType: "subTitle", idx: 1, name: "副标题 2", prompt: "单击此处添加副标题", center: true, x: 0.125, y: 0.5252, w: 0.75, h: 0.2414},
+		}},
+		{layout: LayoutTitleContent, layoutType: "obj", name: "标题和内容", placeholders: []placeholderSpec{
+			titlePlaceholder,
+			bodyPlaceholder,
+		}},
+		{layout: LayoutTwoContent, layoutType: "twoObj", name: "两栏内容", placeholders: []placeholderSpec{
+			titlePlaceholder,
+			{idx: 1, sz: "half", name: "内容占位符 2", prompt: "单击此处添加文本", x: 0.06875, y: 0.2662, w: 0.425, h: 0.6345},
+			{idx: 2, sz: "half", name: "内容占位符 3", prompt: "单击此处添加文本", x: 0.50625, y: 0.2662, w: 0.425, h: 0.6345},
+		}},
+	}
+)
+
+// builtinLayoutIndex 返回布局在 builtinLayouts 中的位置，未知布局按空白处理
+func builtinLayoutIndex(layout SlideLayout) int {
+	for i, l := range builtinLayouts {
+		if l.layout == layout {
+			return i
+		}
+	}
+	return 0
+}
+
 // generateSlideMaster 生成 ppt/slideMasters/slideMaster1.xml
 func (p *Presentation) generateSlideMaster() string {
 	var sb strings.Builder
@@ -31,6 +91,9 @@ func (p *Presentation) generateSlideMaster() string {
 	sb.WriteString(`<a:chExt cx="0" cy="0"/>`)
 	sb.WriteString(`</a:xfrm>`)
 	sb.WriteString(`</p:grpSpPr>`)
+	for i, ph := range masterPlaceholders {
+		sb.WriteString(p.generateLayoutPlaceholder(ph, i+2))
+	}
 	sb.WriteString(`</p:spTree>`)
 	sb.WriteString(`</p:cSld>`)
 
@@ -39,7 +102,13 @@ func (p *Presentation) generateSlideMaster() string {
 
 	// 幻灯片布局ID列表
 	sb.WriteString(`<p:sldLayoutIdLst>`)
-	sb.WriteString(`<p:sldLayoutId id="2147483649" r:id="rId1"/>`)
+	for i := range builtinLayouts {
+		sb.WriteString(`<p:sldLayoutId id="`)
+		sb.WriteString(itoa(2147483649 + i))
+		sb.WriteString(`" r:id="rId`)
+		sb.WriteString(itoa(i + 1))
+		sb.WriteString(`"/>`)
+	}
 	sb.WriteString(`</p:sldLayoutIdLst>`)
 
 	// 文本样式
@@ -69,13 +138,17 @@ func (p *Presentation) generateSlideMaster() string {
 	return sb.String()
 }
 
-// generateSlideLayout 生成 ppt/slideLayouts/slideLayout1.xml (空白布局)
-func (p *Presentation) generateSlideLayout() string {
+// generateSlideLayout 生成 ppt/slideLayouts/slideLayoutN.xml
+func (p *Presentation) generateSlideLayout(layout builtinLayout) string {
 	var sb strings.Builder
 	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
-	sb.WriteString(`<p:sldLayout xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" type="blank" preserve="1">`)
+	sb.WriteString(`<p:sldLayout xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" type="`)
+	sb.WriteString(layout.layoutType)
+	sb.WriteString(`" preserve="1">`)
 
-	sb.WriteString(`<p:cSld name="空白">`)
+	sb.WriteString(`<p:cSld name="`)
+	sb.WriteString(escapeXML(layout.name))
+	sb.WriteString(`">`)
 	sb.WriteString(`<p:spTree>`)
 	sb.WriteString(`<p:nvGrpSpPr>`)
 	sb.WriteString(`<p:cNvPr id="1" name=""/>`)
@@ -90,6 +163,9 @@ func (p *Presentation) generateSlideLayout() string {
 	sb.WriteString(`<a:chExt cx="0" cy="0"/>`)
 	sb.WriteString(`</a:xfrm>`)
 	sb.WriteString(`</p:grpSpPr>`)
+	for i, ph := range layout.placeholders {
+		sb.WriteString(p.generateLayoutPlaceholder(ph, i+2))
+	}
 	sb.WriteString(`</p:spTree>`)
 	sb.WriteString(`</p:cSld>`)
 
@@ -98,3 +174,82 @@ func (p *Presentation) generateSlideLayout() string {
 	sb.WriteString(`</p:sldLayout>`)
 	return sb.String()
 }
+
+// generateLayoutPlaceholder 生成母版或布局中的占位符形状
+func (p *Presentation) generateLayoutPlaceholder(ph placeholderSpec, id int) string {
+	var sb strings.Builder
+	sb.WriteString(`<p:sp>`)
+	sb.WriteString(`<p:nvSpPr>`)
+	sb.WriteString(`<p:cNvPr id="`)
+	sb.WriteString(itoa(id))
+	sb.WriteString(`" name="`)
+	sb.WriteString(escapeXML(ph.name))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>`)
+	sb.WriteString(`<p:nvPr>`)
+	sb.WriteString(placeholderTag(ph.phType, ph.sz, ph.idx))
+	sb.WriteString(`</p:nvPr>`)
+	sb.WriteString(`</p:nvSpPr>`)
+
+	sb.WriteString(`<p:spPr>`)
+	sb.WriteString(`<a:xfrm>`)
+	sb.WriteString(`<a:off x="`)
+	sb.WriteString(itoa(int(float64(p.slideWidth) * ph.x)))
+	sb.WriteString(`" y="`)
+	sb.WriteString(itoa(int(float64(p.slideHeight) * ph.y)))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<a:ext cx="`)
+	sb.WriteString(itoa(int(float64(p.slideWidth) * ph.w)))
+	sb.WriteString(`" cy="`)
+	sb.WriteString(itoa(int(float64(p.slideHeight) * ph.h)))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`</a:xfrm>`)
+	sb.WriteString(`<a:prstGeom prst="rect"><a:avLst/></a:prstGeom>`)
+	sb.WriteString(`</p:spPr>`)
+
+	sb.WriteString(`<p:txBody>`)
+	if ph.phType == "title" || ph.phType == "ctrTitle" {
+		sb.WriteString(`<a:bodyPr anchor="b"/>`)
+	} else {
+		sb.WriteString(`<a:bodyPr/>`)
+	}
+	if ph.center {
+		sb.WriteString(`<a:lstStyle><a:lvl1pPr marL="0" indent="0" algn="ctr"><a:buNone/></a:lvl1pPr></a:lstStyle>`)
+	} else {
+		sb.WriteString(`<a:lstStyle/>`)
+	}
+	sb.WriteString(`<a:p>`)
+	sb.WriteString(`<a:r>`)
+	sb.WriteString(`<a:rPr lang="zh-CN"/>`)
+	sb.WriteString(`<a:t>`)
+	sb.WriteString(escapeXML(ph.prompt))
+	sb.WriteString(`</a:t>`)
+	sb.WriteString(`</a:r>`)
+	sb.WriteString(`</a:p>`)
+	sb.WriteString(`</p:txBody>`)
+	sb.WriteString(`</p:sp>`)
+	return sb.String()
+}
+
+// placeholderTag 生成 p:ph 元素
+func placeholderTag(phType, sz string, idx int) string {
+	var sb strings.Builder
+	sb.WriteString(`<p:ph`)
+	if phType != "" {
+		sb.WriteString(` type="`)
+		sb.WriteString(phType)
+		sb.WriteString(`"`)
+	}
+	if sz != "" {
+		sb.WriteString(` sz="`)
+		sb.WriteString(sz)
+		sb.WriteString(`"`)
+	}
+	if idx > 0 {
+		sb.WriteString(` idx="`)
+		sb.WriteString(itoa(idx))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(`/>`)
+	return sb.String()
+}
@@ -0,0 +1,246 @@
+package genppt
+
+import (
+	"strings"
+)
+
+// hasNotes 是否有幻灯片设置了备注
+func (p *Presentation) hasNotes() bool {
+	for _, slide := range p.slides {
+		if slide.notes != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// notesCount 返回设置了备注的幻灯片数量
+func (p *Presentation) notesCount() int {
+	count := 0
+	for _, slide := range p.slides {
+		if slide.notes != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// needsNotesMaster 是否需要生成备注母版（打开的文件已有备注母版时沿用原有的）
+func (p *Presentation) needsNotesMaster() bool {
+	return p.hasNotes() && (p.pkg == nil || p.pkg.notesMaster == "")
+}
+
+// notesMasterPath 返回备注母版的部件路径
+func (p *Presentation) notesMasterPath() string {
+	if p.pkg != nil && p.pkg.notesMaster != "" {
+		return p.pkg.notesMaster
+	}
+	return "ppt/notesMasters/notesMaster1.xml"
+}
+
+// notesThemePath 返回生成的备注母版使用的主题路径
+func (p *Presentation) notesThemePath() string {
+	if p.pkg != nil {
+		return "ppt/theme/theme" + itoa(p.pkg.themeBase+1) + ".xml"
+	}
+	return "ppt/theme/theme2.xml"
+}
+
+// notesMasterRelID 返回生成的备注母版在 presentation.xml.rels 中的关系ID
+func (p *Presentation) notesMasterRelID() string {
+	if p.pkg == nil {
+		// rId1为母版，随后是幻灯片和4个固定关系
+		return "rId" + itoa(len(p.slides)+6)
+	}
+	next := 1
+	for _, rel := range p.pkg.presRels {
+		next = max(next, atoi(strings.TrimPrefix(rel.id, "rId"))+1)
+	}
+	for _, ref := range p.slideRefs() {
+		next = max(next, atoi(strings.TrimPrefix(ref.rID, "rId"))+1)
+	}
+	return "rId" + itoa(next)
+}
+
+// generateNotesMasterIDList 生成备注母版ID列表 p:notesMasterIdLst
+func (p *Presentation) generateNotesMasterIDList() string {
+	return `<p:notesMasterIdLst><p:notesMasterId r:id="` + p.notesMasterRelID() + `"/></p:notesMasterIdLst>`
+}
+
+// generateNotesMaster 生成 ppt/notesMasters/notesMaster1.xml
+func (p *Presentation) generateNotesMaster() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<p:notesMaster xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">`)
+
+	sb.WriteString(`<p:cSld>`)
+	sb.WriteString(`<p:bg>`)
+	sb.WriteString(`<p:bgRef idx="1001">`)
+	sb.WriteString(`<a:schemeClr val="bg1"/>`)
+	sb.WriteString(`</p:bgRef>`)
+	sb.WriteString(`</p:bg>`)
+	sb.WriteString(`<p:spTree>`)
+	sb.WriteString(`<p:nvGrpSpPr>`)
+	sb.WriteString(`<p:cNvPr id="1" name=""/>`)
+	sb.WriteString(`<p:cNvGrpSpPr/>`)
+	sb.WriteString(`<p:nvPr/>`)
+	sb.WriteString(`</p:nvGrpSpPr>`)
+	sb.WriteString(`<p:grpSpPr>`)
+	sb.WriteString(`<a:xfrm>`)
+	sb.WriteString(`<a:off x="0" y="0"/>`)
+	sb.WriteString(`<a:ext cx="0" cy="0"/>`)
+	sb.WriteString(`<a:chOff x="0" y="0"/>`)
+	sb.WriteString(`<a:chExt cx="0" cy="0"/>`)
+	sb.WriteString(`</a:xfrm>`)
+	sb.WriteString(`</p:grpSpPr>`)
+
+	// 幻灯片缩略图占位符，按幻灯片比例放在备注页上方
+	imgWidth := int64(5486400)
+	imgHeight := imgWidth * p.slideHeight / p.slideWidth
+	sb.WriteString(`<p:sp>`)
+	sb.WriteString(`<p:nvSpPr>`)
+	sb.WriteString(`<p:cNvPr id="2" name="Slide Image Placeholder 1"/>`)
+	sb.WriteString(`<p:cNvSpPr><a:spLocks noGrp="1" noRot="1" noChangeAspect="1"/></p:cNvSpPr>`)
+	sb.WriteString(`<p:nvPr><p:ph type="sldImg" idx="2"/></p:nvPr>`)
+	sb.WriteString(`</p:nvSpPr>`)
+	sb.WriteString(`<p:spPr>`)
+	sb.WriteString(`<a:xfrm><a:off x="685800" y="1143000"/><a:ext cx="`)
+	sb.WriteString(itoa(int(imgWidth)))
+	sb.WriteString(`" cy="`)
+	sb.WriteString(itoa(int(imgHeight)))
+	sb.WriteString(`"/></a:xfrm>`)
+	sb.WriteString(`<a:prstGeom prst="rect"><a:avLst/></a:prstGeom>`)
+	sb.WriteString(`<a:noFill/>`)
+	sb.WriteString(`<a:ln w="12700"><a:solidFill><a:prstClr val="black"/></a:solidFill></a:ln>`)
+	sb.WriteString(`</p:spPr>`)
+	sb.WriteString(`</p:sp>`)
+
+	// 备注正文占位符
+	sb.WriteString(`<p:sp>`)
+	sb.WriteString(`<p:nvSpPr>`)
+	sb.WriteString(`<p:cNvPr id="3" name="Notes Placeholder 2"/>`)
+	sb.WriteString(`<p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>`)
+	sb.WriteString(`<p:nvPr><p:ph type="body" sz="quarter" idx="3"/></p:nvPr>`)
+	sb.WriteString(`</p:nvSpPr>`)
+	sb.WriteString(`<p:spPr>`)
+	sb.WriteString(`<a:xfrm><a:off x="685800" y="4400550"/><a:ext cx="5486400" cy="3600450"/></a:xfrm>`)
+	sb.WriteString(`<a:prstGeom prst="rect"><a:avLst/></a:prstGeom>`)
+	sb.WriteString(`</p:spPr>`)
+	sb.WriteString(`<p:txBody>`)
+	sb.WriteString(`<a:bodyPr vert="horz" lIns="91440" tIns="45720" rIns="91440" bIns="45720" rtlCol="0"/>`)
+	sb.WriteString(`<a:lstStyle/>`)
+	sb.WriteString(`<a:p><a:endParaRPr lang="zh-CN"/></a:p>`)
+	sb.WriteString(`</p:txBody>`)
+	sb.WriteString(`</p:sp>`)
+
+	sb.WriteString(`</p:spTree>`)
+	sb.WriteString(`</p:cSld>`)
+
+	// 颜色映射
+	sb.WriteString(`<p:clrMap bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" accent3="accent3" accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"/>`)
+
+	// 备注文本样式
+	sb.WriteString(`<p:notesStyle>`)
+	sb.WriteString(`<a:lvl1pPr marL="0" algn="l" defTabSz="914400" rtl="0" eaLnBrk="1" latinLnBrk="0" hangingPunct="1">`)
+	sb.WriteString(`<a:defRPr sz="1200" kern="1200"><a:solidFill><a:schemeClr val="tx1"/></a:solidFill><a:latin typeface="+mn-lt"/><a:ea typeface="+mn-ea"/><a:cs typeface="+mn-cs"/></a:defRPr>`)
+	sb.WriteString(`</a:lvl1pPr>`)
+	sb.WriteString(`</p:notesStyle>`)
+
+	sb.WriteString(`</p:notesMaster>`)
+	return sb.String()
+}
+
+// generateNotesMasterRels 生成备注母版关系文件
+func (p *Presentation) generateNotesMasterRels() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	sb.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="../`)
+	sb.WriteString(strings.TrimPrefix(p.notesThemePath(), "ppt/"))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+// generateNotesSlide 生成备注页 ppt/notesSlides/notesSlideN.xml
+func (s *Slide) generateNotesSlide() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<p:notes xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">`)
+
+	sb.WriteString(`<p:cSld>`)
+	sb.WriteString(`<p:spTree>`)
+	sb.WriteString(`<p:nvGrpSpPr>`)
+	sb.WriteString(`<p:cNvPr id="1" name=""/>`)
+	sb.WriteString(`<p:cNvGrpSpPr/>`)
+	sb.WriteString(`<p:nvPr/>`)
+	sb.WriteString(`</p:nvGrpSpPr>`)
+	sb.WriteString(`<p:grpSpPr>`)
+	sb.WriteString(`<a:xfrm>`)
+	sb.WriteString(`<a:off x="0" y="0"/>`)
+	sb.WriteString(`<a:ext cx="0" cy="0"/>`)
+	sb.WriteString(`<a:chOff x="0" y="0"/>`)
+	sb.WriteString(`<a:chExt cx="0" cy="0"/>`)
+	sb.WriteString(`</a:xfrm>`)
+	sb.WriteString(`</p:grpSpPr>`)
+
+	// 幻灯片缩略图
+	sb.WriteString(`<p:sp>`)
+	sb.WriteString(`<p:nvSpPr>`)
+	sb.WriteString(`<p:cNvPr id="2" name="Slide Image Placeholder 1"/>`)
+	sb.WriteString(`<p:cNvSpPr><a:spLocks noGrp="1" noRot="1" noChangeAspect="1"/></p:cNvSpPr>`)
+	sb.WriteString(`<p:nvPr><p:ph type="sldImg"/></p:nvPr>`)
+	sb.WriteString(`</p:nvSpPr>`)
+	sb.WriteString(`<p:spPr/>`)
+	sb.WriteString(`</p:sp>`)
+
+	// 备注正文，每行一个段落
+	sb.WriteString(`<p:sp>`)
+	sb.WriteString(`<p:nvSpPr>`)
+	sb.WriteString(`<p:cNvPr id="3" name="Notes Placeholder 2"/>`)
+	sb.WriteString(`<p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>`)
+	sb.WriteString(`<p:nvPr><p:ph type="body" idx="1"/></p:nvPr>`)
+	sb.WriteString(`</p:nvSpPr>`)
+	sb.WriteString(`<p:spPr/>`)
+	sb.WriteString(`<p:txBody>`)
+	sb.WriteString(`<a:bodyPr/>`)
+	sb.WriteString(`<a:lstStyle/>`)
+	notes := strings.ReplaceAll(s.notes, "\r\n", "\n")
+	for _, line := range strings.Split(notes, "\n") {
+		sb.WriteString(`<a:p>`)
+		if line != "" {
+			sb.WriteString(`<a:r>`)
+			sb.WriteString(`<a:rPr lang="zh-CN" dirty="0"/>`)
+			sb.WriteString(`<a:t>`)
+			sb.WriteString(escapeXML(line))
+			sb.WriteString(`</a:t>`)
+			sb.WriteString(`</a:r>`)
+		} else {
+			sb.WriteString(`<a:endParaRPr lang="zh-CN" dirty="0"/>`)
+		}
+		sb.WriteString(`</a:p>`)
+	}
+	sb.WriteString(`</p:txBody>`)
+	sb.WriteString(`</p:sp>`)
+
+	sb.WriteString(`</p:spTree>`)
+	sb.WriteString(`</p:cSld>`)
+	sb.WriteString(`<p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr>`)
+	sb.WriteString(`</p:notes>`)
+	return sb.String()
+}
+
+// generateNotesSlideRels 生成备注页关系文件
+func (s *Slide) generateNotesSlideRels(slideNum int) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	sb.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesMaster" Target="../`)
+	sb.WriteString(strings.TrimPrefix(s.presentation.notesMasterPath(), "ppt/"))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="../slides/slide`)
+	sb.WriteString(itoa(slideNum))
+	sb.WriteString(`.xml"/>`)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
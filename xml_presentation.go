@@ -7,6 +7,10 @@ import (
 
 // generatePresentation 生成 ppt/presentation.xml
 func (p *Presentation) generatePresentation() string {
+	if p.pkg != nil {
+		return p.generateSourcePresentation()
+	}
+
 	var sb strings.Builder
 	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
 	sb.WriteString(`<p:presentation xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" saveSubsetFonts="1">`)
@@ -16,16 +20,13 @@ func (p *Presentation) generatePresentation() string {
 	sb.WriteString(`<p:sldMasterId id="2147483648" r:id="rId1"/>`)
 	sb.WriteString(`</p:sldMasterIdLst>`)
 
-	// 幻灯片ID列表
-	sb.WriteString(`<p:sldIdLst>`)
-	for i := range p.slides {
-		sb.WriteString(`<p:sldId id="`)
-		sb.WriteString(itoa(256 + i))
-		sb.WriteString(`" r:id="rId`)
-		sb.WriteString(itoa(2 + i)) // rId2开始是幻灯片
-		sb.WriteString(`"/>`)
+	// 备注母版ID列表
+	if p.needsNotesMaster() {
+		sb.WriteString(p.generateNotesMasterIDList())
 	}
-	sb.WriteString(`</p:sldIdLst>`)
+
+	// 幻灯片ID列表
+	sb.WriteString(p.generateSlideIDList())
 
 	// 幻灯片尺寸
 	sb.WriteString(`<p:sldSz cx="`)
@@ -48,6 +49,129 @@ func (p *Presentation) generatePresentation() string {
 	return sb.String()
 }
 
+// generateSlideIDList 生成幻灯片ID列表 p:sldIdLst
+func (p *Presentation) generateSlideIDList() string {
+	var sb strings.Builder
+	sb.WriteString(`<p:sldIdLst>`)
+	for _, ref := range p.slideRefs() {
+		sb.WriteString(`<p:sldId id="`)
+		sb.WriteString(itoa(ref.id))
+		sb.WriteString(`" r:id="`)
+		sb.WriteString(ref.rID)
+		sb.WriteString(`"/>`)
+	}
+	sb.WriteString(`</p:sldIdLst>`)
+	return sb.String()
+}
+
+// generateSourcePresentation 基于打开文件的 presentation.xml 生成，
+// 只替换幻灯片列表和尺寸，其余内容原样保留
+func (p *Presentation) generateSourcePresentation() string {
+	xmlStr := string(p.pkg.presentation)
+	slideList := p.generateSlideIDList()
+
+	// 原文件没有备注母版时，在幻灯片母版列表后加入生成的备注母版
+	if p.needsNotesMaster() {
+		if idx := strings.Index(xmlStr, "</p:sldMasterIdLst>"); idx >= 0 {
+			idx += len("</p:sldMasterIdLst>")
+			xmlStr = xmlStr[:idx] + p.generateNotesMasterIDList() + xmlStr[idx:]
+		}
+	}
+
+	if sldIDLstRe.MatchString(xmlStr) {
+		xmlStr = sldIDLstRe.ReplaceAllLiteralString(xmlStr, slideList)
+	} else {
+		// 幻灯片列表位于各类母版列表之后
+		insertAt := -1
+		for _, tag := range []string{"</p:sldMasterIdLst>", "</p:notesMasterIdLst>", "</p:handoutMasterIdLst>"} {
+			if idx := strings.Index(xmlStr, tag); idx >= 0 {
+				insertAt = max(insertAt, idx+len(tag))
+			}
+		}
+		if insertAt >= 0 {
+			xmlStr = xmlStr[:insertAt] + slideList + xmlStr[insertAt:]
+		}
+	}
+
+	xmlStr = sldSzRe.ReplaceAllStringFunc(xmlStr, func(m string) string {
+		m = replaceAttr(m, "cx", itoa(int(p.slideWidth)))
+		return replaceAttr(m, "cy", itoa(int(p.slideHeight)))
+	})
+	return p.pruneSlideGroups(xmlStr)
+}
+
+// pruneSlideGroups 从节和自定义放映中去掉已不存在的原有幻灯片，并删除因此变空的节和自定义放映
+// 新添加的幻灯片可能复用已删除幻灯片的ID，所以只保留仍在文稿中的原有幻灯片
+func (p *Presentation) pruneSlideGroups(xmlStr string) string {
+	ids := make(map[string]bool)
+	rIDs := make(map[string]bool)
+	for _, slide := range p.slides {
+		if slide.sourceID != 0 {
+			ids[itoa(slide.sourceID)] = true
+			rIDs[slide.sourceRelID] = true
+		}
+	}
+
+	// 节列表 p14:sectionLst，节变空时删除，没有节时删除整个扩展
+	if ext := sectionExtRe.FindString(xmlStr); ext != "" {
+		pruned := sectionRe.ReplaceAllStringFunc(ext, func(section string) string {
+			if !sectionSldIDRe.MatchString(section) {
+				return section
+			}
+			section = sectionSldIDRe.ReplaceAllStringFunc(section, func(m string) string {
+				if ids[sectionSldIDRe.FindStringSubmatch(m)[1]] {
+					return m
+				}
+				return ""
+			})
+			if !sectionSldIDRe.MatchString(section) {
+				return ""
+			}
+			return section
+		})
+		if !sectionRe.MatchString(pruned) {
+			pruned = ""
+		}
+		xmlStr = strings.Replace(xmlStr, ext, pruned, 1)
+	}
+
+	// 自定义放映列表 p:custShowLst，放映变空时删除，没有放映时删除整个列表
+	if lst := custShowLstRe.FindString(xmlStr); lst != "" {
+		pruned := custShowRe.ReplaceAllStringFunc(lst, func(show string) string {
+			show = custSldIDRe.ReplaceAllStringFunc(show, func(m string) string {
+				if rIDs[custSldIDRe.FindStringSubmatch(m)[1]] {
+					return m
+				}
+				return ""
+			})
+			if !custSldIDRe.MatchString(show) {
+				return ""
+			}
+			return show
+		})
+		if !custShowRe.MatchString(pruned) {
+			pruned = ""
+		}
+		xmlStr = strings.Replace(xmlStr, lst, pruned, 1)
+	}
+	return xmlStr
+}
+
+// replaceAttr 替换元素字符串中指定属性的值
+func replaceAttr(element, name, value string) string {
+	key := ` ` + name + `="`
+	start := strings.Index(element, key)
+	if start < 0 {
+		return element
+	}
+	start += len(key)
+	end := strings.Index(element[start:], `"`)
+	if end < 0 {
+		return element
+	}
+	return element[:start] + value + element[start+end:]
+}
+
 // generatePresProps 生成 ppt/presProps.xml
 func generatePresProps() string {
 	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
@@ -82,10 +206,21 @@ func generateViewProps() string {
 </p:viewPr>`
 }
 
-// generateTableStyles 生成 ppt/tableStyles.xml
-func generateTableStyles() string {
-	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<a:tblStyleLst xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" def="{5C22544A-7EE6-4342-B048-85BDC9FD1C3A}"/>`
+// generateTableStyles 生成 ppt/tableStyles.xml，包含自定义表格样式
+func (p *Presentation) generateTableStyles() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<a:tblStyleLst xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" def="`)
+	sb.WriteString(string(TableStyleMedium2Accent1))
+	sb.WriteString(`"`)
+	if len(p.tableStyles) == 0 {
+		sb.WriteString(`/>`)
+		return sb.String()
+	}
+	sb.WriteString(`>`)
+	sb.WriteString(p.generateTableStyleList())
+	sb.WriteString(`</a:tblStyleLst>`)
+	return sb.String()
 }
 
 // generateCoreProps 生成 docProps/core.xml
@@ -138,7 +273,9 @@ func (p *Presentation) generateAppProps() string {
 	sb.WriteString(`<Slides>`)
 	sb.WriteString(itoa(len(p.slides)))
 	sb.WriteString(`</Slides>`)
-	sb.WriteString(`<Notes>0</Notes>`)
+	sb.WriteString(`<Notes>`)
+	sb.WriteString(itoa(p.notesCount()))
+	sb.WriteString(`</Notes>`)
 	sb.WriteString(`<HiddenSlides>0</HiddenSlides>`)
 	sb.WriteString(`<MMClips>0</MMClips>`)
 	sb.WriteString(`<ScaleCrop>false</ScaleCrop>`)
@@ -26,15 +26,54 @@ func (p *Presentation) generateContentTypes() string {
 			sb.WriteString(`"/>`)
 		}
 	}
+	if p.hasCharts() {
+		// 图表嵌入的工作簿
+		mediaExts["xlsx"] = true
+		sb.WriteString(`<Default Extension="xlsx" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"/>`)
+	}
+	if p.pkg != nil {
+		mediaExts["rels"] = true
+		mediaExts["xml"] = true
+		for _, name := range p.pkg.partNames {
+			ext := getExtFromPath(name)
+			contentType, ok := p.pkg.defaults[ext]
+			if !ok || mediaExts[ext] {
+				continue
+			}
+			mediaExts[ext] = true
+			sb.WriteString(`<Default Extension="`)
+			sb.WriteString(ext)
+			sb.WriteString(`" ContentType="`)
+			sb.WriteString(escapeXML(contentType))
+			sb.WriteString(`"/>`)
+		}
+	}
 
 	// Override类型
 	sb.WriteString(`<Override PartName="/ppt/presentation.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"/>`)
-	sb.WriteString(`<Override PartName="/ppt/presProps.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presProps+xml"/>`)
-	sb.WriteString(`<Override PartName="/ppt/viewProps.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.viewProps+xml"/>`)
-	sb.WriteString(`<Override PartName="/ppt/tableStyles.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.tableStyles+xml"/>`)
-	sb.WriteString(`<Override PartName="/ppt/slideMasters/slideMaster1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideMaster+xml"/>`)
-	sb.WriteString(`<Override PartName="/ppt/slideLayouts/slideLayout1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideLayout+xml"/>`)
-	sb.WriteString(`<Override PartName="/ppt/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/>`)
+	if p.pkg != nil {
+		// 原样保留的部件
+		for _, name := range p.pkg.partNames {
+			if contentType, ok := p.pkg.overrides[name]; ok {
+				sb.WriteString(`<Override PartName="/`)
+				sb.WriteString(escapeXML(name))
+				sb.WriteString(`" ContentType="`)
+				sb.WriteString(escapeXML(contentType))
+				sb.WriteString(`"/>`)
+			}
+		}
+	} else {
+		sb.WriteString(`<Override PartName="/ppt/presProps.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presProps+xml"/>`)
+		sb.WriteString(`<Override PartName="/ppt/viewProps.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.viewProps+xml"/>`)
+		sb.WriteString(`<Override PartName="/ppt/tableStyles.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.tableStyles+xml"/>`)
+		sb.WriteString(`<Override PartName="/ppt/slideMasters/slideMaster1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideMaster+xml"/>`)
+		for i := range builtinLayouts {
+			sb.WriteString(`<Override PartName="/ppt/slideLayouts/slideLayout`)
+			sb.WriteString(itoa(i + 1))
+			sb.WriteString(`.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slideLayout+xml"/>`)
+		}
+		sb.WriteString(`<Override PartName="/ppt/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/>`)
+	}
 	sb.WriteString(`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>`)
 	sb.WriteString(`<Override PartName="/docProps/app.xml" ContentType="application/vnd.openxmlformats-officedocument.extended-properties+xml"/>`)
 
@@ -45,15 +84,33 @@ func (p *Presentation) generateContentTypes() string {
 		sb.WriteString(`.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slide+xml"/>`)
 	}
 
+	// 备注页
+	for i, slide := range p.slides {
+		if slide.notes != "" {
+			sb.WriteString(`<Override PartName="/ppt/notesSlides/notesSlide`)
+			sb.WriteString(itoa(i + 1))
+			sb.WriteString(`.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.notesSlide+xml"/>`)
+		}
+	}
+	if p.needsNotesMaster() {
+		sb.WriteString(`<Override PartName="/`)
+		sb.WriteString(p.notesMasterPath())
+		sb.WriteString(`" ContentType="application/vnd.openxmlformats-officedocument.presentationml.notesMaster+xml"/>`)
+		sb.WriteString(`<Override PartName="/`)
+		sb.WriteString(p.notesThemePath())
+		sb.WriteString(`" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/>`)
+	}
+	if p.needsTableStylesPart() {
+		sb.WriteString(`<Override PartName="/ppt/tableStyles.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.tableStyles+xml"/>`)
+	}
+
 	// 图表
-	chartIdx := 1
 	for _, slide := range p.slides {
 		for _, obj := range slide.objects {
-			if _, ok := obj.(*chartObject); ok {
+			if chart, ok := obj.(*chartObject); ok {
 				sb.WriteString(`<Override PartName="/ppt/charts/chart`)
-				sb.WriteString(itoa(chartIdx))
+				sb.WriteString(itoa(chart.chartIdx))
 				sb.WriteString(`.xml" ContentType="application/vnd.openxmlformats-officedocument.drawingml.chart+xml"/>`)
-				chartIdx++
 			}
 		}
 	}
@@ -72,12 +129,75 @@ func generateRootRels() string {
 </Relationships>`
 }
 
+// generateRootRels 生成打开文件的 _rels/.rels，保留原有的其他根关系
+func (pkg *sourcePackage) generateRootRels() string {
+	rels := generateRootRels()
+	if len(pkg.rootRels) == 0 {
+		return rels
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSuffix(rels, `</Relationships>`))
+	for i, rel := range pkg.rootRels {
+		rel.id = "rId" + itoa(4+i)
+		writeRelationship(&sb, rel)
+	}
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+// writeRelationship 写入单个关系元素
+func writeRelationship(sb *strings.Builder, rel packageRel) {
+	sb.WriteString(`<Relationship Id="`)
+	sb.WriteString(escapeXML(rel.id))
+	sb.WriteString(`" Type="`)
+	sb.WriteString(escapeXML(rel.relType))
+	sb.WriteString(`" Target="`)
+	sb.WriteString(escapeXML(rel.target))
+	sb.WriteString(`"`)
+	if rel.targetMode != "" {
+		sb.WriteString(` TargetMode="`)
+		sb.WriteString(escapeXML(rel.targetMode))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(`/>`)
+}
+
 // generatePresentationRels 生成 ppt/_rels/presentation.xml.rels
 func (p *Presentation) generatePresentationRels() string {
 	var sb strings.Builder
 	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
 	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
 
+	if p.pkg != nil {
+		// 保留原有关系（母版、主题、属性等），幻灯片关系重新生成
+		for _, rel := range p.pkg.presRels {
+			writeRelationship(&sb, rel)
+		}
+		for i, ref := range p.slideRefs() {
+			writeRelationship(&sb, packageRel{
+				id:      ref.rID,
+				relType: relTypeSlide,
+				target:  "slides/slide" + itoa(i+1) + ".xml",
+			})
+		}
+		if p.needsNotesMaster() {
+			writeRelationship(&sb, packageRel{
+				id:      p.notesMasterRelID(),
+				relType: relTypeNotesMaster,
+				target:  strings.TrimPrefix(p.notesMasterPath(), "ppt/"),
+			})
+		}
+		if p.needsTableStylesPart() {
+			writeRelationship(&sb, packageRel{
+				id:      p.tableStylesRelID(),
+				relType: relTypeTableStyles,
+				target:  "tableStyles.xml",
+			})
+		}
+		sb.WriteString(`</Relationships>`)
+		return sb.String()
+	}
+
 	rId := 1
 	// 幻灯片母版
 	sb.WriteString(`<Relationship Id="rId`)
@@ -115,36 +235,55 @@ func (p *Presentation) generatePresentationRels() string {
 	sb.WriteString(itoa(rId))
 	sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="theme/theme1.xml"/>`)
 
+	// 备注母版
+	if p.needsNotesMaster() {
+		sb.WriteString(`<Relationship Id="`)
+		sb.WriteString(p.notesMasterRelID())
+		sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesMaster" Target="notesMasters/notesMaster1.xml"/>`)
+	}
+
 	sb.WriteString(`</Relationships>`)
 	return sb.String()
 }
 
 // generateSlideRels 生成幻灯片关系文件
-func (s *Slide) generateSlideRels() string {
+func (s *Slide) generateSlideRels(links slideLinks) string {
 	var sb strings.Builder
 	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
 	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
 
 	// 幻灯片布局关系
-	sb.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>`)
+	sb.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="`)
+	sb.WriteString(escapeXML(s.layoutRelTarget()))
+	sb.WriteString(`"/>`)
+
+	// 备注页关系
+	if s.notes != "" {
+		sb.WriteString(`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide`)
+		sb.WriteString(itoa(s.presentation.slideIndex(s) + 1))
+		sb.WriteString(`.xml"/>`)
+	}
+
+	// 打开文件时保留的关系
+	for _, rel := range s.sourceRels {
+		if rel.slide != nil {
+			if idx := s.presentation.slideIndex(rel.slide); idx >= 0 {
+				rel.target = "slide" + itoa(idx+1) + ".xml"
+			}
+		}
+		writeRelationship(&sb, rel)
+	}
+
+	// 链接关系
+	writeLinkRels(&sb, links)
 
 	// 图片关系
 	for _, obj := range s.objects {
 		if img, ok := obj.(*imageObject); ok {
 			sb.WriteString(`<Relationship Id="`)
 			sb.WriteString(img.rID)
-			sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/image`)
-			// 从rID提取索引
-			idx := 1
-			for i, m := range s.presentation.mediaFiles {
-				if m.rID == img.rID {
-					idx = i + 1
-					break
-				}
-			}
-			sb.WriteString(itoa(idx))
-			sb.WriteString(".")
-			sb.WriteString(img.mediaExt)
+			sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="`)
+			sb.WriteString(s.presentation.mediaTarget(img.rID))
 			sb.WriteString(`"/>`)
 		}
 	}
@@ -152,7 +291,7 @@ func (s *Slide) generateSlideRels() string {
 	// 图表关系
 	for _, obj := range s.objects {
 		if chart, ok := obj.(*chartObject); ok {
-			rID := "rId" + itoa(200+chart.chartIdx)
+			rID := chart.relID()
 			sb.WriteString(`<Relationship Id="`)
 			sb.WriteString(rID)
 			sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart" Target="../charts/chart`)
@@ -164,36 +303,18 @@ func (s *Slide) generateSlideRels() string {
 	// 视频关系
 	for _, obj := range s.objects {
 		if video, ok := obj.(*videoObject); ok {
-			// 找到视频在media列表中的索引
-			videoIdx := 1
-			for i, m := range s.presentation.mediaFiles {
-				if m.rID == video.rID {
-					videoIdx = i + 1
-					break
-				}
-			}
 			sb.WriteString(`<Relationship Id="`)
 			sb.WriteString(video.rID)
-			sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/video" Target="../media/video`)
-			sb.WriteString(itoa(videoIdx))
-			sb.WriteString(".")
-			sb.WriteString(video.mediaExt)
+			sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/video" Target="`)
+			sb.WriteString(s.presentation.mediaTarget(video.rID))
 			sb.WriteString(`"/>`)
 
 			// 封面图片关系
 			if video.posterRID != "" {
 				sb.WriteString(`<Relationship Id="`)
 				sb.WriteString(video.posterRID)
-				sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/poster`)
-				// 找封面图片索引
-				for i, m := range s.presentation.mediaFiles {
-					if m.rID == video.posterRID {
-						sb.WriteString(itoa(i + 1))
-						sb.WriteString(".")
-						sb.WriteString(m.ext)
-						break
-					}
-				}
+				sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="`)
+				sb.WriteString(s.presentation.mediaTarget(video.posterRID))
 				sb.WriteString(`"/>`)
 			}
 		}
@@ -202,20 +323,10 @@ func (s *Slide) generateSlideRels() string {
 	// 音频关系
 	for _, obj := range s.objects {
 		if audio, ok := obj.(*audioObject); ok {
-			// 找到音频在media列表中的索引
-			audioIdx := 1
-			for i, m := range s.presentation.mediaFiles {
-				if m.rID == audio.rID {
-					audioIdx = i + 1
-					break
-				}
-			}
 			sb.WriteString(`<Relationship Id="`)
 			sb.WriteString(audio.rID)
-			sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/audio" Target="../media/audio`)
-			sb.WriteString(itoa(audioIdx))
-			sb.WriteString(".")
-			sb.WriteString(audio.mediaExt)
+			sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/audio" Target="`)
+			sb.WriteString(s.presentation.mediaTarget(audio.rID))
 			sb.WriteString(`"/>`)
 		}
 	}
@@ -226,11 +337,21 @@ func (s *Slide) generateSlideRels() string {
 
 // generateSlideMasterRels 生成幻灯片母版关系文件
 func generateSlideMasterRels() string {
-	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
-<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>
-<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="../theme/theme1.xml"/>
-</Relationships>`
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range builtinLayouts {
+		sb.WriteString(`<Relationship Id="rId`)
+		sb.WriteString(itoa(i + 1))
+		sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout`)
+		sb.WriteString(itoa(i + 1))
+		sb.WriteString(`.xml"/>`)
+	}
+	sb.WriteString(`<Relationship Id="rId`)
+	sb.WriteString(itoa(len(builtinLayouts) + 1))
+	sb.WriteString(`" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="../theme/theme1.xml"/>`)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
 }
 
 // generateSlideLayoutRels 生成幻灯片布局关系文件
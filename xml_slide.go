@@ -5,16 +5,20 @@ import (
 )
 
 // generateSlide 生成幻灯片XML
-func (s *Slide) generateSlide() string {
+func (s *Slide) generateSlide(links slideLinks) string {
 	var sb strings.Builder
 	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
-	sb.WriteString(`<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">`)
+	sb.WriteString(`<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"`)
+	sb.WriteString(s.rawAttrs)
+	sb.WriteString(`>`)
 
 	sb.WriteString(`<p:cSld>`)
 
 	// 背景
 	if s.background != nil {
 		sb.WriteString(s.generateBackground())
+	} else if s.rawBackground != "" {
+		sb.WriteString(s.rawBackground)
 	}
 
 	// 形状树
@@ -34,9 +38,16 @@ func (s *Slide) generateSlide() string {
 	sb.WriteString(`</p:grpSpPr>`)
 
 	// 生成各个对象
-	objectId := 2 // 从2开始，1已被组使用
+	s.linkIDs = links.ids
+	objectId := s.firstObjectID()
 	for _, obj := range s.objects {
 		switch o := obj.(type) {
+		case *rawObject:
+			// 原有形状保留自身的ID
+			sb.WriteString(o.xml)
+		case *placeholderObject:
+			sb.WriteString(s.generatePlaceholder(o, objectId))
+			objectId++
 		case *textObject:
 			sb.WriteString(s.generateTextBox(o, objectId))
 			objectId++
@@ -65,55 +76,167 @@ func (s *Slide) generateSlide() string {
 	sb.WriteString(`</p:cSld>`)
 	sb.WriteString(`<p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr>`)
 
-	// 生成时间轴（用于自动播放媒体）
-	timing := s.generateTiming()
-	if timing != "" {
-		sb.WriteString(timing)
-	}
+	sb.WriteString(s.generateTransition())
 
+	// 生成时间轴（用于自动播放媒体和对象动画）
+	sb.WriteString(s.generateTiming())
+
+	sb.WriteString(s.rawExtLst)
 	sb.WriteString(`</p:sld>`)
 
 	return sb.String()
 }
 
-// generateTiming 生成时间轴XML（用于自动播放媒体）
+// firstObjectID 返回新对象的起始ID（1已被组使用，打开的幻灯片从原有形状的最大ID之后开始）
+func (s *Slide) firstObjectID() int {
+	return max(2, s.maxShapeID+1)
+}
+
+// layoutHasPlaceholder 幻灯片使用的布局中是否有指定序号的占位符
+func (s *Slide) layoutHasPlaceholder(idx int) bool {
+	if s.presentation.pkg == nil {
+		for _, spec := range builtinLayouts[builtinLayoutIndex(s.layout)].placeholders {
+			if spec.idx == idx {
+				return true
+			}
+		}
+		return false
+	}
+	target := s.layoutRelTarget()
+	for _, l := range s.presentation.pkg.layouts {
+		if l.relTarget() != target {
+			continue
+		}
+		for _, i := range l.phIndexes {
+			if i == idx {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// generatePlaceholder 生成填充布局占位符的形状，位置和样式由布局决定
+func (s *Slide) generatePlaceholder(ph *placeholderObject, id int) string {
+	var phType, sz, name string
+	switch {
+	case ph.idx == 0 && s.layout == LayoutTitle:
+		phType, name = "ctrTitle", "标题 "
+	case ph.idx == 0:
+		phType, name = "title", "标题 "
+	case s.layout == LayoutTitle:
+		phType, name = "subTitle", "副标题 "
+	case s.layout == LayoutTwoContent:
+		sz, name = "half", "内容占位符 "
+	default:
+		name = "内容占位符 "
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<p:sp>`)
+	sb.WriteString(`<p:nvSpPr>`)
+	sb.WriteString(`<p:cNvPr id="`)
+	sb.WriteString(itoa(id))
+	sb.WriteString(`" name="`)
+	sb.WriteString(name)
+	sb.WriteString(itoa(id - 1))
+	sb.WriteString(`"/>`)
+	sb.WriteString(`<p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>`)
+	sb.WriteString(`<p:nvPr>`)
+	sb.WriteString(placeholderTag(phType, sz, ph.idx))
+	sb.WriteString(`</p:nvPr>`)
+	sb.WriteString(`</p:nvSpPr>`)
+
+	// 布局中没有右栏占位符时无从继承位置，显式指定两栏布局中右栏的位置
+	if ph.idx == 2 && !s.layoutHasPlaceholder(2) {
+		spec := builtinLayouts[builtinLayoutIndex(LayoutTwoContent)].placeholders[2]
+		sb.WriteString(`<p:spPr>`)
+		sb.WriteString(`<a:xfrm>`)
+		sb.WriteString(`<a:off x="`)
+		sb.WriteString(itoa(int(float64(s.presentation.slideWidth) * spec.x)))
+		sb.WriteString(`" y="`)
+		sb.WriteString(itoa(int(float64(s.presentation.slideHeight) * spec.y)))
+		sb.WriteString(`"/>`)
+		sb.WriteString(`<a:ext cx="`)
+		sb.WriteString(itoa(int(float64(s.presentation.slideWidth) * spec.w)))
+		sb.WriteString(`" cy="`)
+		sb.WriteString(itoa(int(float64(s.presentation.slideHeight) * spec.h)))
+		sb.WriteString(`"/>`)
+		sb.WriteString(`</a:xfrm>`)
+		sb.WriteString(`</p:spPr>`)
+	} else {
+		sb.WriteString(`<p:spPr/>`)
+	}
+
+	sb.WriteString(`<p:txBody>`)
+	sb.WriteString(`<a:bodyPr/>`)
+	sb.WriteString(`<a:lstStyle/>`)
+	text := strings.ReplaceAll(ph.text, "\r\n", "\n")
+	for _, line := range strings.Split(text, "\n") {
+		sb.WriteString(`<a:p>`)
+		if line != "" {
+			sb.WriteString(`<a:r>`)
+			sb.WriteString(`<a:rPr lang="zh-CN" dirty="0"/>`)
+			sb.WriteString(`<a:t>`)
+			sb.WriteString(escapeXML(line))
+			sb.WriteString(`</a:t>`)
+			sb.WriteString(`</a:r>`)
+		} else {
+			sb.WriteString(`<a:endParaRPr lang="zh-CN" dirty="0"/>`)
+		}
+		sb.WriteString(`</a:p>`)
+	}
+	sb.WriteString(`</p:txBody>`)
+	sb.WriteString(`</p:sp>`)
+	return sb.String()
+}
+
+// generateTiming 生成时间轴XML（用于自动播放媒体和对象动画）
+// 打开的幻灯片已有时间轴时，新的媒体和动画合并到原有时间轴中
 func (s *Slide) generateTiming() string {
 	// 收集需要自动播放的媒体对象
-	var autoPlayMedia []struct {
-		id      int
-		isVideo bool
-		loop    bool
-	}
+	var autoPlayMedia []int
+	var steps []animationStep
+	var builds []*animationBuild
 
-	objectId := 2
+	objectId := s.firstObjectID()
 	for _, obj := range s.objects {
+		if _, ok := obj.(*rawObject); ok {
+			continue
+		}
+		objSteps, build := animationSteps(obj, objectId)
+		steps = append(steps, objSteps...)
+		if build != nil {
+			builds = append(builds, build)
+		}
 		switch o := obj.(type) {
 		case *videoObject:
 			if o.options.AutoPlay {
-				autoPlayMedia = append(autoPlayMedia, struct {
-					id      int
-					isVideo bool
-					loop    bool
-				}{objectId, true, o.options.Loop})
+				autoPlayMedia = append(autoPlayMedia, objectId)
 			}
-			objectId++
 		case *audioObject:
 			if o.options.AutoPlay {
-				autoPlayMedia = append(autoPlayMedia, struct {
-					id      int
-					isVideo bool
-					loop    bool
-				}{objectId, false, o.options.Loop})
+				autoPlayMedia = append(autoPlayMedia, objectId)
 			}
-			objectId++
-		default:
-			objectId++
 		}
+		objectId++
 	}
 
-	if len(autoPlayMedia) == 0 {
-		return ""
+	if len(autoPlayMedia) == 0 && len(steps) == 0 {
+		return s.rawTiming
+	}
+	if s.rawTiming != "" {
+		if merged, ok := mergeTiming(s.rawTiming, autoPlayMedia, steps, builds); ok {
+			return merged
+		}
+	}
+
+	timeline := &timelineWriter{tnID: 3}
+	for _, id := range autoPlayMedia {
+		timeline.writeMediaCall(id)
 	}
+	timeline.writeAnimations(steps)
 
 	var sb strings.Builder
 	sb.WriteString(`<p:timing>`)
@@ -121,85 +244,12 @@ func (s *Slide) generateTiming() string {
 	sb.WriteString(`<p:par>`)
 	sb.WriteString(`<p:cTn id="1" dur="indefinite" restart="never" nodeType="tmRoot">`)
 	sb.WriteString(`<p:childTnLst>`)
-	sb.WriteString(`<p:seq concurrent="1" nextAc="seek">`)
-	sb.WriteString(`<p:cTn id="2" dur="indefinite" nodeType="mainSeq">`)
-	sb.WriteString(`<p:childTnLst>`)
-
-	tnId := 3
-	for i, media := range autoPlayMedia {
-		sb.WriteString(`<p:par>`)
-		sb.WriteString(`<p:cTn id="`)
-		sb.WriteString(itoa(tnId))
-		tnId++
-		sb.WriteString(`" fill="hold">`)
-		sb.WriteString(`<p:stCondLst>`)
-		if i == 0 {
-			// 第一个媒体在幻灯片显示时开始
-			sb.WriteString(`<p:cond delay="0"/>`)
-		} else {
-			// 后续媒体也同时开始
-			sb.WriteString(`<p:cond delay="0"/>`)
-		}
-		sb.WriteString(`</p:stCondLst>`)
-		sb.WriteString(`<p:childTnLst>`)
-		sb.WriteString(`<p:par>`)
-		sb.WriteString(`<p:cTn id="`)
-		sb.WriteString(itoa(tnId))
-		tnId++
-		sb.WriteString(`" fill="hold">`)
-		sb.WriteString(`<p:stCondLst>`)
-		sb.WriteString(`<p:cond delay="0"/>`)
-		sb.WriteString(`</p:stCondLst>`)
-		sb.WriteString(`<p:childTnLst>`)
-		sb.WriteString(`<p:par>`)
-		sb.WriteString(`<p:cTn id="`)
-		sb.WriteString(itoa(tnId))
-		tnId++
-		sb.WriteString(`" presetID="1" presetClass="mediacall" presetSubtype="0" fill="hold" nodeType="afterEffect">`)
-		sb.WriteString(`<p:stCondLst>`)
-		sb.WriteString(`<p:cond delay="0"/>`)
-		sb.WriteString(`</p:stCondLst>`)
-		sb.WriteString(`<p:childTnLst>`)
-
-		// 媒体命令
-		sb.WriteString(`<p:cmd type="call" cmd="playFrom(0.0)">`)
-		sb.WriteString(`<p:cBhvr>`)
-		sb.WriteString(`<p:cTn id="`)
-		sb.WriteString(itoa(tnId))
-		tnId++
-		sb.WriteString(`" dur="1" fill="hold"/>`)
-		sb.WriteString(`<p:tgtEl>`)
-		sb.WriteString(`<p:spTgt spid="`)
-		sb.WriteString(itoa(media.id))
-		sb.WriteString(`"/>`)
-		sb.WriteString(`</p:tgtEl>`)
-		sb.WriteString(`</p:cBhvr>`)
-		sb.WriteString(`</p:cmd>`)
-
-		sb.WriteString(`</p:childTnLst>`)
-		sb.WriteString(`</p:cTn>`)
-		sb.WriteString(`</p:par>`)
-		sb.WriteString(`</p:childTnLst>`)
-		sb.WriteString(`</p:cTn>`)
-		sb.WriteString(`</p:par>`)
-		sb.WriteString(`</p:childTnLst>`)
-		sb.WriteString(`</p:cTn>`)
-		sb.WriteString(`</p:par>`)
-	}
-
-	sb.WriteString(`</p:childTnLst>`)
-	sb.WriteString(`</p:cTn>`)
-	sb.WriteString(`<p:prevCondLst>`)
-	sb.WriteString(`<p:cond evt="onPrev" delay="0"><p:tgtEl><p:sldTgt/></p:tgtEl></p:cond>`)
-	sb.WriteString(`</p:prevCondLst>`)
-	sb.WriteString(`<p:nextCondLst>`)
-	sb.WriteString(`<p:cond evt="onNext" delay="0"><p:tgtEl><p:sldTgt/></p:tgtEl></p:cond>`)
-	sb.WriteString(`</p:nextCondLst>`)
-	sb.WriteString(`</p:seq>`)
+	sb.WriteString(generateMainSequence(2, timeline.sb.String()))
 	sb.WriteString(`</p:childTnLst>`)
 	sb.WriteString(`</p:cTn>`)
 	sb.WriteString(`</p:par>`)
 	sb.WriteString(`</p:tnLst>`)
+	sb.WriteString(generateBuildList(builds))
 	sb.WriteString(`</p:timing>`)
 
 	return sb.String()
@@ -213,9 +263,7 @@ func (s *Slide) generateBackground() string {
 
 	if s.background.Color != "" {
 		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(s.background.Color))
-		sb.WriteString(`"/>`)
+		sb.WriteString(colorElement(s.background.Color, ""))
 		sb.WriteString(`</a:solidFill>`)
 	}
 
@@ -268,9 +316,7 @@ func (s *Slide) generateTextBox(t *textObject, id int) string {
 	sb.WriteString(`<a:prstGeom prst="rect"><a:avLst/></a:prstGeom>`)
 	if t.options.Fill != "" {
 		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(t.options.Fill))
-		sb.WriteString(`"/>`)
+		sb.WriteString(colorElement(t.options.Fill, ""))
 		sb.WriteString(`</a:solidFill>`)
 	} else {
 		sb.WriteString(`<a:noFill/>`)
@@ -290,72 +336,9 @@ func (s *Slide) generateTextBox(t *textObject, id int) string {
 	sb.WriteString(`<a:lstStyle/>`)
 
 	// 段落
-	sb.WriteString(`<a:p>`)
-	sb.WriteString(`<a:pPr`)
-	if t.options.Align != "" {
-		sb.WriteString(` algn="`)
-		sb.WriteString(string(t.options.Align))
-		sb.WriteString(`"`)
-	}
-	sb.WriteString(`>`)
-	if t.options.LineSpacing > 0 {
-		sb.WriteString(`<a:lnSpc><a:spcPct val="`)
-		sb.WriteString(itoa(int(t.options.LineSpacing * 100000))) // 100% = 100000
-		sb.WriteString(`"/></a:lnSpc>`)
-	}
-	sb.WriteString(`</a:pPr>`)
-
-	// 文本运行
-	sb.WriteString(`<a:r>`)
-	sb.WriteString(`<a:rPr lang="zh-CN"`)
-	if t.options.FontSize > 0 {
-		sb.WriteString(` sz="`)
-		sb.WriteString(itoa(int(t.options.FontSize * 100)))
-		sb.WriteString(`"`)
+	for _, para := range t.textParagraphs() {
+		s.writeParagraph(&sb, para, t.options)
 	}
-	if t.options.Bold {
-		sb.WriteString(` b="1"`)
-	}
-	if t.options.Italic {
-		sb.WriteString(` i="1"`)
-	}
-	if t.options.Underline {
-		sb.WriteString(` u="sng"`)
-	}
-	if t.options.CharSpacing != 0 {
-		sb.WriteString(` spc="`)
-		sb.WriteString(itoa(int(t.options.CharSpacing * 100))) // 1pt = 100
-		sb.WriteString(`"`)
-	}
-	sb.WriteString(`>`)
-
-	// 字体颜色
-	if t.options.FontColor != "" {
-		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(t.options.FontColor))
-		sb.WriteString(`"/>`)
-		sb.WriteString(`</a:solidFill>`)
-	}
-
-	// 字体
-	if t.options.FontFace != "" {
-		sb.WriteString(`<a:latin typeface="`)
-		sb.WriteString(escapeXML(t.options.FontFace))
-		sb.WriteString(`"/>`)
-		sb.WriteString(`<a:ea typeface="`)
-		sb.WriteString(escapeXML(t.options.FontFace))
-		sb.WriteString(`"/>`)
-	}
-
-	sb.WriteString(`</a:rPr>`)
-	sb.WriteString(`<a:t>`)
-	sb.WriteString(escapeXML(t.text))
-	sb.WriteString(`</a:t>`)
-	sb.WriteString(`</a:r>`)
-
-	sb.WriteString(`<a:endParaRPr lang="zh-CN"/>`)
-	sb.WriteString(`</a:p>`)
 	sb.WriteString(`</p:txBody>`)
 	sb.WriteString(`</p:sp>`)
 
@@ -409,7 +392,9 @@ func (s *Slide) generateShape(sh *shapeObject, id int) string {
 	sb.WriteString(itoa(id))
 	sb.WriteString(`" name="Shape `)
 	sb.WriteString(itoa(id))
-	sb.WriteString(`"/>`)
+	sb.WriteString(`">`)
+	sb.WriteString(s.hlinkClick(sh.options.Link))
+	sb.WriteString(`</p:cNvPr>`)
 	sb.WriteString(`<p:cNvSpPr/>`)
 	sb.WriteString(`<p:nvPr/>`)
 	sb.WriteString(`</p:nvSpPr>`)
@@ -443,16 +428,11 @@ func (s *Slide) generateShape(sh *shapeObject, id int) string {
 	// 填充
 	if sh.options.Fill != "" {
 		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(sh.options.Fill))
-		sb.WriteString(`"`)
+		alpha := ""
 		if sh.options.Transparency > 0 {
-			sb.WriteString(`><a:alpha val="`)
-			sb.WriteString(itoa(int((100 - sh.options.Transparency) * 1000)))
-			sb.WriteString(`"/></a:srgbClr>`)
-		} else {
-			sb.WriteString(`/>`)
+			alpha = `<a:alpha val="` + itoa(int((100-sh.options.Transparency)*1000)) + `"/>`
 		}
+		sb.WriteString(colorElement(sh.options.Fill, alpha))
 		sb.WriteString(`</a:solidFill>`)
 	} else {
 		sb.WriteString(`<a:noFill/>`)
@@ -464,9 +444,7 @@ func (s *Slide) generateShape(sh *shapeObject, id int) string {
 		sb.WriteString(itoa(int(sh.options.LineWidth * 12700)))
 		sb.WriteString(`">`)
 		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(sh.options.LineColor))
-		sb.WriteString(`"/>`)
+		sb.WriteString(colorElement(sh.options.LineColor, ""))
 		sb.WriteString(`</a:solidFill>`)
 		sb.WriteString(`</a:ln>`)
 	}
@@ -576,9 +554,7 @@ func (s *Slide) generateTable(t *tableObject, id int) string {
 	sb.WriteString(`<a:tbl>`)
 
 	// 表格属性
-	sb.WriteString(`<a:tblPr firstRow="1" bandRow="1">`)
-	sb.WriteString(`<a:tableStyleId>{5C22544A-7EE6-4342-B048-85BDC9FD1C3A}</a:tableStyleId>`)
-	sb.WriteString(`</a:tblPr>`)
+	sb.WriteString(generateTableProps(t.options))
 
 	// 表格网格
 	sb.WriteString(`<a:tblGrid>`)
@@ -604,7 +580,7 @@ func (s *Slide) generateTable(t *tableObject, id int) string {
 			if colIdx >= numCols {
 				break
 			}
-			sb.WriteString(s.generateTableCell(&cell, t, rowIdx))
+			sb.WriteString(s.generateTableCell(&cell, t, rowIdx, colIdx))
 		}
 
 		sb.WriteString(`</a:tr>`)
@@ -619,7 +595,7 @@ func (s *Slide) generateTable(t *tableObject, id int) string {
 }
 
 // generateTableCell 生成表格单元格
-func (s *Slide) generateTableCell(cell *TableCell, t *tableObject, rowIdx int) string {
+func (s *Slide) generateTableCell(cell *TableCell, t *tableObject, rowIdx, colIdx int) string {
 	var sb strings.Builder
 
 	sb.WriteString(`<a:tc`)
@@ -635,84 +611,46 @@ func (s *Slide) generateTableCell(cell *TableCell, t *tableObject, rowIdx int) s
 	}
 	sb.WriteString(`>`)
 
-	// 文本体
+	// 文本体，与文本框使用相同的段落格式
 	sb.WriteString(`<a:txBody>`)
 	sb.WriteString(`<a:bodyPr/>`)
 	sb.WriteString(`<a:lstStyle/>`)
-	sb.WriteString(`<a:p>`)
-
-	// 段落属性
-	align := cell.Align
-	if align == "" {
-		align = AlignLeft
-	}
-	sb.WriteString(`<a:pPr algn="`)
-	sb.WriteString(string(align))
-	sb.WriteString(`"/>`)
-
-	// 文本运行
-	sb.WriteString(`<a:r>`)
-	sb.WriteString(`<a:rPr lang="zh-CN"`)
-
-	// 字号
-	fontSize := cell.FontSize
-	if fontSize == 0 {
-		fontSize = t.options.FontSize
-	}
-	if fontSize > 0 {
-		sb.WriteString(` sz="`)
-		sb.WriteString(itoa(int(fontSize * 100)))
-		sb.WriteString(`"`)
-	}
-
-	// 粗体
-	isBold := cell.Bold || (rowIdx == 0 && t.options.FirstRowBold)
-	if isBold {
-		sb.WriteString(` b="1"`)
+	opts := t.cellTextOptions(cell, rowIdx)
+	for _, para := range cell.paragraphs() {
+		s.writeParagraph(&sb, para, opts)
 	}
-	if cell.Italic {
-		sb.WriteString(` i="1"`)
-	}
-	sb.WriteString(`>`)
-
-	// 字体颜色
-	fontColor := cell.FontColor
-	if fontColor == "" {
-		fontColor = t.options.FontColor
-	}
-	if fontColor != "" {
-		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(fontColor))
-		sb.WriteString(`"/>`)
-		sb.WriteString(`</a:solidFill>`)
-	}
-
-	// 字体
-	fontFace := cell.FontFace
-	if fontFace == "" {
-		fontFace = t.options.FontFace
-	}
-	if fontFace != "" {
-		sb.WriteString(`<a:latin typeface="`)
-		sb.WriteString(escapeXML(fontFace))
-		sb.WriteString(`"/>`)
-		sb.WriteString(`<a:ea typeface="`)
-		sb.WriteString(escapeXML(fontFace))
-		sb.WriteString(`"/>`)
-	}
-
-	sb.WriteString(`</a:rPr>`)
-	sb.WriteString(`<a:t>`)
-	sb.WriteString(escapeXML(cell.Text))
-	sb.WriteString(`</a:t>`)
-	sb.WriteString(`</a:r>`)
-	sb.WriteString(`<a:endParaRPr lang="zh-CN"/>`)
-	sb.WriteString(`</a:p>`)
 	sb.WriteString(`</a:txBody>`)
 
 	// 单元格属性
 	sb.WriteString(`<a:tcPr`)
+	// 内边距，只输出设置了的边
+	if m := cell.Margins; m != nil {
+		margins := []struct {
+			name  string
+			value float64
+		}{
+			{"marL", m.Left},
+			{"marR", m.Right},
+			{"marT", m.Top},
+			{"marB", m.Bottom},
+		}
+		for _, mar := range margins {
+			if mar.value == 0 {
+				continue
+			}
+			sb.WriteString(` `)
+			sb.WriteString(mar.name)
+			sb.WriteString(`="`)
+			sb.WriteString(itoa(int(InchToEMU(mar.value))))
+			sb.WriteString(`"`)
+		}
+	}
+	// 文字方向
+	if cell.TextDirection != "" {
+		sb.WriteString(` vert="`)
+		sb.WriteString(string(cell.TextDirection))
+		sb.WriteString(`"`)
+	}
 	// 垂直对齐
 	vAlign := cell.VAlign
 	if vAlign == "" {
@@ -722,6 +660,24 @@ func (s *Slide) generateTableCell(cell *TableCell, t *tableObject, rowIdx int) s
 	sb.WriteString(string(vAlign))
 	sb.WriteString(`">`)
 
+	// 边框和斜线，顺序由 schema 规定
+	lines := []struct {
+		tag    string
+		border *Border
+	}{
+		{"a:lnL", t.cellBorder(rowIdx, colIdx, edgeLeft)},
+		{"a:lnR", t.cellBorder(rowIdx, colIdx, edgeRight)},
+		{"a:lnT", t.cellBorder(rowIdx, colIdx, edgeTop)},
+		{"a:lnB", t.cellBorder(rowIdx, colIdx, edgeBottom)},
+		{"a:lnTlToBr", cell.DiagonalDown},
+		{"a:lnBlToTr", cell.DiagonalUp},
+	}
+	for _, line := range lines {
+		if line.border != nil {
+			sb.WriteString(tableLine(line.tag, t.options.Border.inherit(*line.border)))
+		}
+	}
+
 	// 单元格填充
 	fillColor := cell.Fill
 	if fillColor == "" {
@@ -733,34 +689,10 @@ func (s *Slide) generateTableCell(cell *TableCell, t *tableObject, rowIdx int) s
 	}
 	if fillColor != "" {
 		sb.WriteString(`<a:solidFill>`)
-		sb.WriteString(`<a:srgbClr val="`)
-		sb.WriteString(ParseColor(fillColor))
-		sb.WriteString(`"/>`)
+		sb.WriteString(colorElement(fillColor, ""))
 		sb.WriteString(`</a:solidFill>`)
 	}
 
-	// 边框
-	if t.options.Border.Color != "" {
-		borderWidth := int(t.options.Border.Width * 12700)
-		borderColor := ParseColor(t.options.Border.Color)
-
-		borders := []string{"lnL", "lnR", "lnT", "lnB"}
-		for _, b := range borders {
-			sb.WriteString(`<a:`)
-			sb.WriteString(b)
-			sb.WriteString(` w="`)
-			sb.WriteString(itoa(borderWidth))
-			sb.WriteString(`" cap="flat" cmpd="sng" algn="ctr">`)
-			sb.WriteString(`<a:solidFill><a:srgbClr val="`)
-			sb.WriteString(borderColor)
-			sb.WriteString(`"/></a:solidFill>`)
-			sb.WriteString(`<a:prstDash val="solid"/>`)
-			sb.WriteString(`</a:`)
-			sb.WriteString(b)
-			sb.WriteString(`>`)
-		}
-	}
-
 	sb.WriteString(`</a:tcPr>`)
 	sb.WriteString(`</a:tc>`)
 
@@ -788,7 +720,9 @@ func (s *Slide) generateImage(img *imageObject, id int) string {
 		sb.WriteString(escapeXML(img.options.AltText))
 		sb.WriteString(`"`)
 	}
-	sb.WriteString(`/>`)
+	sb.WriteString(`>`)
+	sb.WriteString(s.hlinkClick(img.options.Link))
+	sb.WriteString(`</p:cNvPr>`)
 	sb.WriteString(`<p:cNvPicPr><a:picLocks noChangeAspect="1"/></p:cNvPicPr>`)
 	sb.WriteString(`<p:nvPr/>`)
 	sb.WriteString(`</p:nvPicPr>`)
@@ -0,0 +1,191 @@
+package genppt
+
+import (
+	"strings"
+)
+
+// textToParagraphs 将纯文本按换行拆分为段落，每段一个文本运行
+func textToParagraphs(text string) []Paragraph {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	paragraphs := make([]Paragraph, 0, len(lines))
+	for _, line := range lines {
+		para := Paragraph{}
+		if line != "" {
+			para.Runs = []TextRun{{Text: line}}
+		}
+		paragraphs = append(paragraphs, para)
+	}
+	return paragraphs
+}
+
+// textParagraphs 返回文本对象的段落
+func (t *textObject) textParagraphs() []Paragraph {
+	if len(t.paragraphs) > 0 {
+		return t.paragraphs
+	}
+	return textToParagraphs(t.text)
+}
+
+// writeParagraph 写入段落 a:p，未设置的段落和文字格式沿用 opts
+func (s *Slide) writeParagraph(sb *strings.Builder, para Paragraph, opts TextOptions) {
+	sb.WriteString(`<a:p>`)
+
+	// 段落属性
+	sb.WriteString(`<a:pPr`)
+	level := max(0, min(para.Level, 8))
+	bullet := para.Numbered || para.Bullet != ""
+	if bullet {
+		// 悬挂缩进，项目符号位于缩进位置
+		sb.WriteString(` marL="`)
+		sb.WriteString(itoa(level*457200 + 228600))
+		sb.WriteString(`" indent="-228600"`)
+	} else if level > 0 {
+		sb.WriteString(` marL="`)
+		sb.WriteString(itoa(level * 457200))
+		sb.WriteString(`"`)
+	}
+	if level > 0 {
+		sb.WriteString(` lvl="`)
+		sb.WriteString(itoa(level))
+		sb.WriteString(`"`)
+	}
+	align := para.Align
+	if align == "" {
+		align = opts.Align
+	}
+	if align != "" {
+		sb.WriteString(` algn="`)
+		sb.WriteString(string(align))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(`>`)
+	lineSpacing := defaultIfZero(para.LineSpacing, opts.LineSpacing)
+	if lineSpacing > 0 {
+		sb.WriteString(`<a:lnSpc><a:spcPct val="`)
+		sb.WriteString(itoa(int(lineSpacing * 100000))) // 100% = 100000
+		sb.WriteString(`"/></a:lnSpc>`)
+	}
+	if para.SpaceBefore > 0 {
+		sb.WriteString(`<a:spcBef><a:spcPts val="`)
+		sb.WriteString(itoa(int(para.SpaceBefore * 100))) // 1pt = 100
+		sb.WriteString(`"/></a:spcBef>`)
+	}
+	if para.SpaceAfter > 0 {
+		sb.WriteString(`<a:spcAft><a:spcPts val="`)
+		sb.WriteString(itoa(int(para.SpaceAfter * 100)))
+		sb.WriteString(`"/></a:spcAft>`)
+	}
+	if para.Numbered {
+		sb.WriteString(`<a:buAutoNum type="arabicPeriod"/>`)
+	} else if para.Bullet != "" {
+		sb.WriteString(`<a:buFont typeface="Arial"/>`)
+		sb.WriteString(`<a:buChar char="`)
+		sb.WriteString(escapeXML(para.Bullet))
+		sb.WriteString(`"/>`)
+	}
+	sb.WriteString(`</a:pPr>`)
+
+	// 文本运行，运行内的换行生成 a:br
+	for _, run := range para.Runs {
+		for i, line := range strings.Split(strings.ReplaceAll(run.Text, "\r\n", "\n"), "\n") {
+			if i > 0 {
+				sb.WriteString(`<a:br>`)
+				s.writeRunProps(sb, "a:rPr", run, opts)
+				sb.WriteString(`</a:br>`)
+			}
+			if line == "" {
+				continue
+			}
+			sb.WriteString(`<a:r>`)
+			s.writeRunProps(sb, "a:rPr", run, opts)
+			sb.WriteString(`<a:t>`)
+			sb.WriteString(escapeXML(line))
+			sb.WriteString(`</a:t>`)
+			sb.WriteString(`</a:r>`)
+		}
+	}
+
+	if len(para.Runs) == 0 {
+		// 空段落保留字号，保证空行高度
+		s.writeRunProps(sb, "a:endParaRPr", TextRun{}, opts)
+	} else {
+		sb.WriteString(`<a:endParaRPr lang="zh-CN"/>`)
+	}
+	sb.WriteString(`</a:p>`)
+}
+
+// writeRunProps 写入文本运行属性（a:rPr 或 a:endParaRPr），未设置的格式沿用 opts
+func (s *Slide) writeRunProps(sb *strings.Builder, tag string, run TextRun, opts TextOptions) {
+	sb.WriteString(`<`)
+	sb.WriteString(tag)
+	sb.WriteString(` lang="zh-CN"`)
+	fontSize := defaultIfZero(run.FontSize, opts.FontSize)
+	if fontSize > 0 {
+		sb.WriteString(` sz="`)
+		sb.WriteString(itoa(int(fontSize * 100)))
+		sb.WriteString(`"`)
+	}
+	// 文本运行明确取消时输出关闭值，覆盖 opts 和占位符继承的格式
+	if boolOr(run.Bold, opts.Bold) {
+		sb.WriteString(` b="1"`)
+	} else if run.Bold != nil {
+		sb.WriteString(` b="0"`)
+	}
+	if boolOr(run.Italic, opts.Italic) {
+		sb.WriteString(` i="1"`)
+	} else if run.Italic != nil {
+		sb.WriteString(` i="0"`)
+	}
+	if boolOr(run.Underline, opts.Underline) {
+		sb.WriteString(` u="sng"`)
+	} else if run.Underline != nil {
+		sb.WriteString(` u="none"`)
+	}
+	if run.Strike {
+		sb.WriteString(` strike="sngStrike"`)
+	}
+	if run.Superscript {
+		sb.WriteString(` baseline="30000"`)
+	} else if run.Subscript {
+		sb.WriteString(` baseline="-25000"`)
+	}
+	if opts.CharSpacing != 0 {
+		sb.WriteString(` spc="`)
+		sb.WriteString(itoa(int(opts.CharSpacing * 100))) // 1pt = 100
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(`>`)
+
+	// 字体颜色
+	fontColor := defaultIfEmpty(run.FontColor, opts.FontColor)
+	if fontColor != "" {
+		sb.WriteString(`<a:solidFill>`)
+		sb.WriteString(colorElement(fontColor, ""))
+		sb.WriteString(`</a:solidFill>`)
+	}
+
+	// 字体
+	fontFace := defaultIfEmpty(run.FontFace, opts.FontFace)
+	if fontFace != "" {
+		sb.WriteString(`<a:latin typeface="`)
+		sb.WriteString(escapeXML(fontTypeface(fontFace, "lt")))
+		sb.WriteString(`"/>`)
+		sb.WriteString(`<a:ea typeface="`)
+		sb.WriteString(escapeXML(fontTypeface(fontFace, "ea")))
+		sb.WriteString(`"/>`)
+	}
+
+	// 点击链接
+	if tag == "a:rPr" {
+		link := run.Link
+		if link == nil {
+			link = opts.Link
+		}
+		sb.WriteString(s.hlinkClick(link))
+	}
+
+	sb.WriteString(`</`)
+	sb.WriteString(tag)
+	sb.WriteString(`>`)
+}
@@ -1,45 +1,130 @@
 package genppt
 
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	clrSchemeRe  = regexp.MustCompile(`(?s)<a:clrScheme\b.*?</a:clrScheme>`)
+	fontSchemeRe = regexp.MustCompile(`(?s)<a:fontScheme\b.*?</a:fontScheme>`)
+)
+
+// currentTheme 返回演示文稿使用的主题
+func (p *Presentation) currentTheme() Theme {
+	if p.theme != nil {
+		return *p.theme
+	}
+	return DefaultTheme()
+}
+
 // generateTheme 生成 ppt/theme/theme1.xml
-func generateTheme() string {
-	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="Office Theme">
+func (p *Presentation) generateTheme() string {
+	theme := p.currentTheme()
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="`)
+	sb.WriteString(escapeXML(theme.Name))
+	sb.WriteString(` Theme">
 <a:themeElements>
-<a:clrScheme name="Office">
-<a:dk1><a:sysClr val="windowText" lastClr="000000"/></a:dk1>
-<a:lt1><a:sysClr val="window" lastClr="FFFFFF"/></a:lt1>
-<a:dk2><a:srgbClr val="44546A"/></a:dk2>
-<a:lt2><a:srgbClr val="E7E6E6"/></a:lt2>
-<a:accent1><a:srgbClr val="4472C4"/></a:accent1>
-<a:accent2><a:srgbClr val="ED7D31"/></a:accent2>
-<a:accent3><a:srgbClr val="A5A5A5"/></a:accent3>
-<a:accent4><a:srgbClr val="FFC000"/></a:accent4>
-<a:accent5><a:srgbClr val="5B9BD5"/></a:accent5>
-<a:accent6><a:srgbClr val="70AD47"/></a:accent6>
-<a:hlink><a:srgbClr val="0563C1"/></a:hlink>
-<a:folHlink><a:srgbClr val="954F72"/></a:folHlink>
-</a:clrScheme>
-<a:fontScheme name="Office">
-<a:majorFont>
-<a:latin typeface="Calibri Light"/>
-<a:ea typeface=""/>
-<a:cs typeface=""/>
-<a:font script="Jpan" typeface="游ゴシック Light"/>
-<a:font script="Hang" typeface="맑은 고딕"/>
-<a:font script="Hans" typeface="等线 Light"/>
-<a:font script="Hant" typeface="新細明體"/>
-</a:majorFont>
-<a:minorFont>
-<a:latin typeface="Calibri"/>
-<a:ea typeface=""/>
-<a:cs typeface=""/>
-<a:font script="Jpan" typeface="游ゴシック"/>
-<a:font script="Hang" typeface="맑은 고딕"/>
-<a:font script="Hans" typeface="等线"/>
-<a:font script="Hant" typeface="新細明體"/>
-</a:minorFont>
-</a:fontScheme>
-<a:fmtScheme name="Office">
+`)
+	sb.WriteString(generateColorScheme(theme))
+	sb.WriteString("\n")
+	sb.WriteString(generateFontScheme(theme))
+	sb.WriteString("\n")
+	sb.WriteString(themeFormatScheme)
+	return sb.String()
+}
+
+// applyTheme 替换现有主题部件中的配色和字体方案
+func (p *Presentation) applyTheme(data []byte) []byte {
+	theme := p.currentTheme()
+	xmlStr := clrSchemeRe.ReplaceAllLiteralString(string(data), generateColorScheme(theme))
+	xmlStr = fontSchemeRe.ReplaceAllLiteralString(xmlStr, generateFontScheme(theme))
+	return []byte(xmlStr)
+}
+
+// generateColorScheme 生成配色方案 a:clrScheme
+func generateColorScheme(theme Theme) string {
+	var sb strings.Builder
+	sb.WriteString(`<a:clrScheme name="`)
+	sb.WriteString(escapeXML(theme.Name))
+	sb.WriteString(`">`)
+	colors := []struct {
+		tag   string
+		color string
+	}{
+		{"dk1", theme.Dark1},
+		{"lt1", theme.Light1},
+		{"dk2", theme.Dark2},
+		{"lt2", theme.Light2},
+		{"accent1", theme.Accent1},
+		{"accent2", theme.Accent2},
+		{"accent3", theme.Accent3},
+		{"accent4", theme.Accent4},
+		{"accent5", theme.Accent5},
+		{"accent6", theme.Accent6},
+		{"hlink", theme.Hyperlink},
+		{"folHlink", theme.FollowedHyperlink},
+	}
+	for _, c := range colors {
+		sb.WriteString("\n<a:")
+		sb.WriteString(c.tag)
+		sb.WriteString(`><a:srgbClr val="`)
+		sb.WriteString(ParseColor(c.color))
+		sb.WriteString(`"/></a:`)
+		sb.WriteString(c.tag)
+		sb.WriteString(`>`)
+	}
+	sb.WriteString("\n</a:clrScheme>")
+	return sb.String()
+}
+
+// generateFontScheme 生成字体方案 a:fontScheme
+func generateFontScheme(theme Theme) string {
+	var sb strings.Builder
+	sb.WriteString(`<a:fontScheme name="`)
+	sb.WriteString(escapeXML(theme.Name))
+	sb.WriteString(`">`)
+	sb.WriteString("\n<a:majorFont>")
+	writeThemeFonts(&sb, theme.MajorFont)
+	sb.WriteString("\n</a:majorFont>")
+	sb.WriteString("\n<a:minorFont>")
+	writeThemeFonts(&sb, theme.MinorFont)
+	sb.WriteString("\n</a:minorFont>")
+	sb.WriteString("\n</a:fontScheme>")
+	return sb.String()
+}
+
+// writeThemeFonts 写入一组主题字体
+func writeThemeFonts(sb *strings.Builder, fonts ThemeFonts) {
+	sb.WriteString("\n<a:latin typeface=\"")
+	sb.WriteString(escapeXML(fonts.Latin))
+	sb.WriteString("\"/>")
+	sb.WriteString("\n<a:ea typeface=\"")
+	sb.WriteString(escapeXML(fonts.EastAsian))
+	sb.WriteString("\"/>")
+	sb.WriteString("\n<a:cs typeface=\"")
+	sb.WriteString(escapeXML(fonts.ComplexScript))
+	sb.WriteString("\"/>")
+
+	scripts := make([]string, 0, len(fonts.Scripts))
+	for script := range fonts.Scripts {
+		scripts = append(scripts, script)
+	}
+	sort.Strings(scripts)
+	for _, script := range scripts {
+		sb.WriteString("\n<a:font script=\"")
+		sb.WriteString(escapeXML(script))
+		sb.WriteString("\" typeface=\"")
+		sb.WriteString(escapeXML(fonts.Scripts[script]))
+		sb.WriteString("\"/>")
+	}
+}
+
+// themeFormatScheme 主题的格式方案及结尾部分
+const themeFormatScheme = `<a:fmtScheme name="Office">
 <a:fillStyleLst>
 <a:solidFill><a:schemeClr val="phClr"/></a:solidFill>
 <a:gradFill rotWithShape="1">
@@ -86,4 +171,3 @@ func generateTheme() string {
 <a:objectDefaults/>
 <a:extraClrSchemeLst/>
 </a:theme>`
-}
@@ -0,0 +1,299 @@
+package genppt
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+)
+
+// workbookCell 嵌入工作簿中的单元格
+type workbookCell struct {
+	value   string // 单元格内容，数值已格式化
+	numeric bool   // 是否为数值
+	format  string // 数字格式，为空时使用常规格式
+}
+
+// numberCell 返回数值单元格，NaN 和无穷大返回空单元格
+func numberCell(val float64, format string) workbookCell {
+	if !isFinite(val) {
+		return workbookCell{}
+	}
+	return workbookCell{value: ftoa(val), numeric: true, format: format}
+}
+
+// columnName 返回列号（从0开始）对应的列名，如 0 -> A、26 -> AA
+func columnName(col int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+// sheetRef 返回 Sheet1 中一列单元格区域的公式引用（行号从1开始）
+func sheetRef(col, firstRow, lastRow int) string {
+	ref := "Sheet1!$" + columnName(col) + "$" + itoa(firstRow)
+	if lastRow != firstRow {
+		ref += ":$" + columnName(col) + "$" + itoa(lastRow)
+	}
+	return ref
+}
+
+// hasCharts 演示文稿中是否有新添加的图表
+func (p *Presentation) hasCharts() bool {
+	for _, slide := range p.slides {
+		for _, obj := range slide.objects {
+			if _, ok := obj.(*chartObject); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// workbookPath 返回图表嵌入工作簿在包中的路径
+func (c *chartObject) workbookPath() string {
+	return "ppt/embeddings/Microsoft_Excel_Worksheet" + itoa(c.workbookIdx) + ".xlsx"
+}
+
+// generateChartRels 生成图表关系文件，指向嵌入的工作簿
+func (c *chartObject) generateChartRels() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	sb.WriteString(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/package" Target="../embeddings/Microsoft_Excel_Worksheet`)
+	sb.WriteString(itoa(c.workbookIdx))
+	sb.WriteString(`.xlsx"/>`)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+// worksheetRows 返回图表数据在工作表中的内容
+// 第一行为系列名称，A列为类别（取类别标签或日期最多的系列，数量相同时取靠前的），第N个系列的数值位于第N+1列
+func (c *chartObject) worksheetRows() [][]workbookCell {
+	if c.isXY() {
+		return c.xyWorksheetRows()
+	}
+
+	// 类别列：使用日期类别时为带日期格式的序列号
+	var categories []workbookCell
+	if c.hasDates() {
+		for _, t := range c.categoryDates() {
+			categories = append(categories, numberCell(dateSerial(t), c.dateFormat()))
+		}
+	}
+	for _, series := range c.series {
+		if !c.hasDates() && len(series.Labels) > len(categories) {
+			categories = categories[:0]
+			for _, label := range series.Labels {
+				categories = append(categories, workbookCell{value: label})
+			}
+		}
+	}
+	rowCount := len(categories)
+	for _, series := range c.series {
+		rowCount = max(rowCount, len(series.Values))
+	}
+
+	rows := make([][]workbookCell, rowCount+1)
+	for i := range rows {
+		rows[i] = make([]workbookCell, len(c.series)+1)
+	}
+	for i, cell := range categories {
+		rows[i+1][0] = cell
+	}
+	for col, series := range c.series {
+		rows[0][col+1] = workbookCell{value: series.Name}
+		for i, val := range series.Values {
+			rows[i+1][col+1] = numberCell(val, series.NumberFormat)
+		}
+	}
+	return rows
+}
+
+// xyWorksheetRows 返回散点图和气泡图数据在工作表中的内容，每个系列依次占用X值、Y值（和大小）列
+func (c *chartObject) xyWorksheetRows() [][]workbookCell {
+	rowCount := 0
+	for _, series := range c.series {
+		rowCount = max(rowCount, max(len(series.xValues()), max(len(series.Values), len(series.bubbleSizes()))))
+	}
+	_, _, lastCol := c.xyColumns(len(c.series) - 1)
+
+	rows := make([][]workbookCell, rowCount+1)
+	for i := range rows {
+		rows[i] = make([]workbookCell, max(0, lastCol+1))
+	}
+	setColumn := func(col int, header string, values []float64, format string) {
+		rows[0][col] = workbookCell{value: header}
+		for i, val := range values {
+			rows[i+1][col] = numberCell(val, format)
+		}
+	}
+	for idx, series := range c.series {
+		xCol, yCol, sizeCol := c.xyColumns(idx)
+		setColumn(xCol, "X 值", series.xValues(), "")
+		setColumn(yCol, series.Name, series.Values, series.NumberFormat)
+		if c.chartType == ChartBubble {
+			setColumn(sizeCol, "大小", series.bubbleSizes(), "")
+		}
+	}
+	return rows
+}
+
+// generateWorkbook 生成只包含 Sheet1 的最小 xlsx 工作簿
+func generateWorkbook(rows [][]workbookCell) ([]byte, error) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	// 每种数字格式一个单元格样式，样式0为常规格式
+	var formats []string
+	styles := make(map[string]int)
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell.format != "" && styles[cell.format] == 0 {
+				formats = append(formats, cell.format)
+				styles[cell.format] = len(formats)
+			}
+		}
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", generateWorkbookContentTypes()},
+		{"_rels/.rels", generateWorkbookRootRels()},
+		{"xl/workbook.xml", generateWorkbookXML()},
+		{"xl/_rels/workbook.xml.rels", generateWorkbookRels()},
+		{"xl/styles.xml", generateWorkbookStyles(formats)},
+		{"xl/worksheets/sheet1.xml", generateWorksheet(rows, styles)},
+	}
+	for _, f := range files {
+		writer, err := zipWriter.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(f.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// generateWorkbookContentTypes 生成工作簿的 [Content_Types].xml
+func generateWorkbookContentTypes() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+}
+
+// generateWorkbookRootRels 生成工作簿的 _rels/.rels
+func generateWorkbookRootRels() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+}
+
+// generateWorkbookXML 生成 xl/workbook.xml
+func generateWorkbookXML() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+}
+
+// generateWorkbookRels 生成 xl/_rels/workbook.xml.rels
+func generateWorkbookRels() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+}
+
+// generateWorkbookStyles 生成 xl/styles.xml，自定义数字格式从164开始编号
+func generateWorkbookStyles(formats []string) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(formats) > 0 {
+		sb.WriteString(`<numFmts count="`)
+		sb.WriteString(itoa(len(formats)))
+		sb.WriteString(`">`)
+		for i, format := range formats {
+			sb.WriteString(`<numFmt numFmtId="`)
+			sb.WriteString(itoa(164 + i))
+			sb.WriteString(`" formatCode="`)
+			sb.WriteString(escapeXML(format))
+			sb.WriteString(`"/>`)
+		}
+		sb.WriteString(`</numFmts>`)
+	}
+	sb.WriteString(`<fonts count="1"><font><sz val="11"/><name val="Calibri"/><family val="2"/></font></fonts>`)
+	sb.WriteString(`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>`)
+	sb.WriteString(`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	sb.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	sb.WriteString(`<cellXfs count="`)
+	sb.WriteString(itoa(len(formats) + 1))
+	sb.WriteString(`">`)
+	sb.WriteString(`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>`)
+	for i := range formats {
+		sb.WriteString(`<xf numFmtId="`)
+		sb.WriteString(itoa(164 + i))
+		sb.WriteString(`" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`)
+	}
+	sb.WriteString(`</cellXfs>`)
+	sb.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	sb.WriteString(`</styleSheet>`)
+	return sb.String()
+}
+
+// generateWorksheet 生成 xl/worksheets/sheet1.xml，文本使用内联字符串，styles 为数字格式对应的样式序号
+func generateWorksheet(rows [][]workbookCell, styles map[string]int) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	sb.WriteString(`<sheetData>`)
+	for r, row := range rows {
+		sb.WriteString(`<row r="`)
+		sb.WriteString(itoa(r + 1))
+		sb.WriteString(`">`)
+		for col, cell := range row {
+			if cell.value == "" {
+				continue
+			}
+			ref := columnName(col) + itoa(r+1)
+			if cell.numeric {
+				sb.WriteString(`<c r="`)
+				sb.WriteString(ref)
+				if cell.format != "" {
+					sb.WriteString(`" s="`)
+					sb.WriteString(itoa(styles[cell.format]))
+				}
+				sb.WriteString(`"><v>`)
+				sb.WriteString(cell.value)
+				sb.WriteString(`</v></c>`)
+			} else {
+				sb.WriteString(`<c r="`)
+				sb.WriteString(ref)
+				sb.WriteString(`" t="inlineStr"><is><t>`)
+				sb.WriteString(escapeXML(cell.value))
+				sb.WriteString(`</t></is></c>`)
+			}
+		}
+		sb.WriteString(`</row>`)
+	}
+	sb.WriteString(`</sheetData>`)
+	sb.WriteString(`</worksheet>`)
+	return sb.String()
+}